@@ -0,0 +1,65 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package cloud
+
+import (
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/backoff"
+)
+
+// BackoffConfig configures the delays between the failed attempts to connect to the cloud. The delay starts at
+// InitialInterval and doubles with each consecutive failure up to MaxInterval. Each delay is randomized to spread the
+// reconnects of many devices in time.
+type BackoffConfig struct {
+	// InitialInterval is the delay after the first failure, the tick interval is used when it is not set.
+	InitialInterval time.Duration
+	// MaxInterval limits the delay between the attempts.
+	MaxInterval time.Duration
+	// MaxRetries is the number of consecutive failures after which the manager stops connecting and reports
+	// the failed provisioning status. DefaultMaxRetries is used when it is 0, a negative value means unlimited retries.
+	MaxRetries int
+}
+
+const (
+	defaultMaxBackoffInterval = time.Minute * 5
+	// DefaultMaxRetries spreads the attempts over roughly one hour with the default intervals.
+	DefaultMaxRetries = 20
+)
+
+func (b BackoffConfig) normalize(tickInterval time.Duration) BackoffConfig {
+	if b.InitialInterval <= 0 {
+		b.InitialInterval = tickInterval
+	}
+	if b.MaxInterval <= 0 {
+		b.MaxInterval = defaultMaxBackoffInterval
+	}
+	if b.MaxInterval < b.InitialInterval {
+		b.MaxInterval = b.InitialInterval
+	}
+	if b.MaxRetries == 0 {
+		b.MaxRetries = DefaultMaxRetries
+	}
+	return b
+}
+
+// delay returns the delay before the next attempt after the given number of consecutive failures.
+func (b BackoffConfig) delay(failures int) time.Duration {
+	return backoff.Delay(b.InitialInterval, b.MaxInterval, failures)
+}
@@ -0,0 +1,121 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package cloud
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/pkg/eventloop"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	b := BackoffConfig{MaxInterval: time.Second * 5}.normalize(time.Second)
+	require.Equal(t, time.Second, b.InitialInterval)
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		for range 10 {
+			d := b.delay(i + 1)
+			require.GreaterOrEqual(t, d, w/2)
+			require.LessOrEqual(t, d, w)
+		}
+	}
+
+	b = BackoffConfig{InitialInterval: time.Minute, MaxInterval: time.Second}.normalize(time.Second)
+	require.Equal(t, time.Minute, b.MaxInterval)
+	b = BackoffConfig{}.normalize(time.Second)
+	require.Equal(t, defaultMaxBackoffInterval, b.MaxInterval)
+	require.Equal(t, DefaultMaxRetries, b.MaxRetries)
+	b = BackoffConfig{MaxRetries: -1}.normalize(time.Second)
+	require.Equal(t, -1, b.MaxRetries)
+}
+
+func TestToLastErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "OK", want: LastErrorCode_OK},
+		{name: "Network", err: errors.New("connection refused"), want: LastErrorCode_CONNECT},
+		{name: "TLSAlert", err: fmt.Errorf("cannot dial: %w", tls.AlertError(42)), want: LastErrorCode_TLS},
+		{name: "UnknownAuthority", err: fmt.Errorf("cannot dial: %w", x509.UnknownAuthorityError{}), want: LastErrorCode_TLS},
+		{name: "InvalidCloudCertificate", err: fmt.Errorf("%w: invalid cloud id", ErrInvalidCloudCertificate), want: LastErrorCode_TLS},
+		{name: "SignUpRejected", err: errCannotSignUp(StatusCodeError{Code: codes.Forbidden}), want: LastErrorCode_SIGN_UP_REJECTED},
+		{name: "SignUpMissingCode", err: errCannotSignUp(ErrMissingAuthorizationCode), want: LastErrorCode_SIGN_UP_REJECTED},
+		{name: "SignUpTimeout", err: errCannotSignUp(context.DeadlineExceeded), want: LastErrorCode_CONNECT},
+		{name: "RefreshTokenRejected", err: errCannotRefreshToken(StatusCodeError{Code: codes.Unauthorized}), want: LastErrorCode_REFRESH_ACCESS_TOKEN},
+		{name: "SignInUnauthorized", err: errCannotSignIn(StatusCodeError{Code: codes.Unauthorized}), want: LastErrorCode_REFRESH_ACCESS_TOKEN},
+		{name: "SignInMissingAccessToken", err: errCannotSignIn(ErrMissingAccessToken), want: LastErrorCode_REFRESH_ACCESS_TOKEN},
+		{name: "SignInInternalError", err: errCannotSignIn(StatusCodeError{Code: codes.InternalServerError}), want: LastErrorCode_RESPONSE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, toLastErrorCode(tt.err))
+		})
+	}
+}
+
+func TestManagerBackoff(t *testing.T) {
+	changed := 0
+	m, err := New(Config{
+		CloudID:               uuid.NewString(),
+		AuthorizationProvider: "plgd",
+		CloudURL:              "coaps+tcp://127.0.0.1:1",
+	}, uuid.New(), func() {
+		// do nothing
+	}, nil, nil, MakeCAPool(func() []*x509.Certificate {
+		return nil
+	}, true), eventloop.New(),
+		WithBackoff(BackoffConfig{InitialInterval: time.Millisecond * 100, MaxRetries: 2}),
+		WithOnConfigurationChanged(func() {
+			changed++
+		}))
+	require.NoError(t, err)
+	require.Equal(t, cloud.ProvisioningStatus_READY_TO_REGISTER, m.GetCloudConfiguration().ProvisioningStatus)
+	changed = 0
+
+	m.handleConnect(context.Background())
+	cfg := m.GetCloudConfiguration()
+	require.Equal(t, LastErrorCode_CONNECT, cfg.LastErrorCode)
+	require.Equal(t, cloud.ProvisioningStatus_READY_TO_REGISTER, cfg.ProvisioningStatus)
+	require.Equal(t, 1, m.failures)
+	require.Equal(t, 1, changed)
+
+	m.handleConnect(context.Background())
+	cfg = m.GetCloudConfiguration()
+	require.Equal(t, cloud.ProvisioningStatus_FAILED, cfg.ProvisioningStatus)
+	require.True(t, m.isFailed())
+	require.Equal(t, 2, changed)
+
+	// reconnect restores the retry budget
+	m.resetFailures()
+	require.Equal(t, 0, m.failures)
+	require.Equal(t, cloud.ProvisioningStatus_READY_TO_REGISTER, m.GetCloudConfiguration().ProvisioningStatus)
+	require.Equal(t, 3, changed)
+}
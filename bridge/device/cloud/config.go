@@ -32,4 +32,6 @@ type Configuration struct {
 	LastErrorCode         int                      `yaml:"-" json:"clec"`
 	ProvisioningStatus    cloud.ProvisioningStatus `yaml:"-" json:"cps"`
 	AuthorizationCode     string                   `yaml:"-" json:"-"`
+	// Endpoints contains all cloud endpoints, URL and CloudID are set from the active one.
+	Endpoints []cloud.Endpoint `yaml:"-" json:"x.org.iotivity.servers,omitempty"`
 }
@@ -0,0 +1,87 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package cloud
+
+import (
+	"github.com/plgd-dev/device/v2/schema/cloud"
+)
+
+// makeEndpoints returns the cloud endpoints of the configuration, the endpoint set by cis is always part of the list.
+func makeEndpoints(cfg cloud.ConfigurationUpdateRequest) []cloud.Endpoint {
+	if cfg.URL == "" {
+		return nil
+	}
+	endpoints := make([]cloud.Endpoint, 0, len(cfg.Endpoints)+1)
+	contains := func(uri string) bool {
+		for _, ep := range endpoints {
+			if ep.URI == uri {
+				return true
+			}
+		}
+		return false
+	}
+	for _, ep := range cfg.Endpoints {
+		if ep.URI == "" || contains(ep.URI) {
+			continue
+		}
+		if ep.URI == cfg.URL && ep.ID == "" {
+			ep.ID = cfg.CloudID
+		}
+		endpoints = append(endpoints, ep)
+	}
+	if !contains(cfg.URL) {
+		endpoints = append([]cloud.Endpoint{{ID: cfg.CloudID, URI: cfg.URL}}, endpoints...)
+	}
+	return endpoints
+}
+
+// selectNextEndpoint sets cis and sid to the endpoint following the active one, it returns false when there is no
+// alternative endpoint.
+func (c *Manager) selectNextEndpoint() (cloud.Endpoint, bool) {
+	c.private.mutex.Lock()
+	defer c.private.mutex.Unlock()
+	endpoints := c.private.cfg.Endpoints
+	if len(endpoints) < 2 {
+		return cloud.Endpoint{}, false
+	}
+	idx := -1
+	for i, ep := range endpoints {
+		if ep.URI == c.private.cfg.URL {
+			idx = i
+			break
+		}
+	}
+	next := endpoints[(idx+1)%len(endpoints)]
+	c.private.cfg.URL = next.URI
+	if next.ID != "" {
+		c.private.cfg.CloudID = next.ID
+	}
+	return next, true
+}
+
+// failover switches to the next cloud endpoint, the connection is established with it on the next attempt.
+func (c *Manager) failover(err error) {
+	next, ok := c.selectNextEndpoint()
+	if !ok {
+		return
+	}
+	c.logger.Infof("switching cloud endpoint to %v: %v", next.URI, err)
+	c.onConfigurationChanged()
+	c.save()
+}
@@ -0,0 +1,140 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package cloud
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/pkg/eventloop"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeEndpoints(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  cloud.ConfigurationUpdateRequest
+		want []cloud.Endpoint
+	}{
+		{
+			name: "Empty",
+			cfg: cloud.ConfigurationUpdateRequest{
+				Endpoints: []cloud.Endpoint{{ID: "id", URI: "coaps+tcp://dr:5684"}},
+			},
+		},
+		{
+			name: "OnlyURL",
+			cfg:  cloud.ConfigurationUpdateRequest{CloudID: "primary", URL: "coaps+tcp://primary:5684"},
+			want: []cloud.Endpoint{{ID: "primary", URI: "coaps+tcp://primary:5684"}},
+		},
+		{
+			name: "URLIsPrepended",
+			cfg: cloud.ConfigurationUpdateRequest{
+				CloudID:   "primary",
+				URL:       "coaps+tcp://primary:5684",
+				Endpoints: []cloud.Endpoint{{ID: "dr", URI: "coaps+tcp://dr:5684"}, {ID: "dr", URI: "coaps+tcp://dr:5684"}, {ID: "invalid"}},
+			},
+			want: []cloud.Endpoint{{ID: "primary", URI: "coaps+tcp://primary:5684"}, {ID: "dr", URI: "coaps+tcp://dr:5684"}},
+		},
+		{
+			name: "OrderIsKept",
+			cfg: cloud.ConfigurationUpdateRequest{
+				CloudID:   "dr",
+				URL:       "coaps+tcp://dr:5684",
+				Endpoints: []cloud.Endpoint{{ID: "primary", URI: "coaps+tcp://primary:5684"}, {URI: "coaps+tcp://dr:5684"}},
+			},
+			want: []cloud.Endpoint{{ID: "primary", URI: "coaps+tcp://primary:5684"}, {ID: "dr", URI: "coaps+tcp://dr:5684"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, makeEndpoints(tt.cfg))
+		})
+	}
+}
+
+func TestManagerFailover(t *testing.T) {
+	saved := 0
+	m, err := New(Config{}, uuid.New(), func() {
+		saved++
+	}, nil, nil, MakeCAPool(nil, true), eventloop.New())
+	require.NoError(t, err)
+
+	// single endpoint
+	m.setCloudConfiguration(cloud.ConfigurationUpdateRequest{
+		AuthorizationProvider: "plgd",
+		CloudID:               "primary",
+		URL:                   "coaps+tcp://primary:5684",
+	})
+	m.failover(errors.New("dial failed"))
+	require.Equal(t, 0, saved)
+	require.Equal(t, "coaps+tcp://primary:5684", m.GetCloudConfiguration().URL)
+
+	m.setCloudConfiguration(cloud.ConfigurationUpdateRequest{
+		AuthorizationProvider: "plgd",
+		CloudID:               "primary",
+		URL:                   "coaps+tcp://primary:5684",
+		Endpoints:             []cloud.Endpoint{{ID: "dr", URI: "coaps+tcp://dr:5684"}},
+	})
+	m.failover(errors.New("dial failed"))
+	require.Equal(t, 1, saved)
+	cfg := m.GetCloudConfiguration()
+	require.Equal(t, "coaps+tcp://dr:5684", cfg.URL)
+	require.Equal(t, "dr", cfg.CloudID)
+	require.Len(t, cfg.Endpoints, 2)
+
+	// active endpoint is restored
+	exported := m.ExportConfig()
+	require.Equal(t, "coaps+tcp://dr:5684", exported.CloudURL)
+	m2, err := New(exported, uuid.New(), func() {
+		// do nothing
+	}, nil, nil, MakeCAPool(nil, true), eventloop.New())
+	require.NoError(t, err)
+	cfg2 := m2.GetCloudConfiguration()
+	require.Equal(t, cfg.URL, cfg2.URL)
+	require.Equal(t, cfg.CloudID, cfg2.CloudID)
+	require.Equal(t, cfg.Endpoints, cfg2.Endpoints)
+
+	// endpoints are rotated
+	m.failover(errors.New("sign in failed"))
+	cfg = m.GetCloudConfiguration()
+	require.Equal(t, "coaps+tcp://primary:5684", cfg.URL)
+	require.Equal(t, "primary", cfg.CloudID)
+}
+
+func TestManagerDialFailover(t *testing.T) {
+	m, err := New(Config{}, uuid.New(), func() {
+		// do nothing
+	}, nil, nil, MakeCAPool(func() []*x509.Certificate { return nil }, true), eventloop.New())
+	require.NoError(t, err)
+	m.setCloudConfiguration(cloud.ConfigurationUpdateRequest{
+		AuthorizationProvider: "plgd",
+		CloudID:               "primary",
+		URL:                   "coaps+tcp://primary",
+		Endpoints:             []cloud.Endpoint{{ID: "dr", URI: "coaps+tcp://dr:5684"}},
+	})
+	// the invalid address of the active endpoint switches to the next one
+	err = m.dial(context.Background())
+	require.Error(t, err)
+	require.Equal(t, "coaps+tcp://dr:5684", m.GetCloudConfiguration().URL)
+}
@@ -0,0 +1,95 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package cloud
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+)
+
+// Last error codes reported by clec of the cloud configuration resource. The codes up to
+// LastErrorCode_REFRESH_ACCESS_TOKEN are compatible with IoTivity-Lite.
+const (
+	LastErrorCode_OK                   = 0
+	LastErrorCode_RESPONSE             = 1 // cloud responded with an unexpected error
+	LastErrorCode_CONNECT              = 2 // cloud is not reachable
+	LastErrorCode_REFRESH_ACCESS_TOKEN = 3 // access token has expired and it cannot be refreshed
+	LastErrorCode_TLS                  = 4 // TLS handshake or the verification of the cloud certificate failed
+	LastErrorCode_SIGN_UP_REJECTED     = 5 // cloud rejected the authorization code
+)
+
+var ErrInvalidCloudCertificate = errors.New("invalid cloud certificate")
+
+// StatusCodeError is returned when the cloud responds with an unexpected status code.
+type StatusCodeError struct {
+	Code codes.Code
+}
+
+func (e StatusCodeError) Error() string {
+	return fmt.Sprintf("unexpected status code %v", e.Code)
+}
+
+func isTLSError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var alertErr tls.AlertError
+	var recordErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	return errors.Is(err, ErrInvalidCloudCertificate) ||
+		errors.As(err, &verificationErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &recordErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &hostnameErr)
+}
+
+// toLastErrorCode classifies the error returned by the connection to the cloud.
+func toLastErrorCode(err error) int {
+	if err == nil {
+		return LastErrorCode_OK
+	}
+	if isTLSError(err) {
+		return LastErrorCode_TLS
+	}
+	if errors.Is(err, ErrMissingAuthorizationCode) || errors.Is(err, ErrMissingAuthorizationProvider) {
+		return LastErrorCode_SIGN_UP_REJECTED
+	}
+	if errors.Is(err, ErrMissingAccessToken) {
+		return LastErrorCode_REFRESH_ACCESS_TOKEN
+	}
+	var statusErr StatusCodeError
+	if !errors.As(err, &statusErr) {
+		return LastErrorCode_CONNECT
+	}
+	switch {
+	case errors.Is(err, ErrCannotSignUp):
+		return LastErrorCode_SIGN_UP_REJECTED
+	case errors.Is(err, ErrCannotRefreshToken):
+		return LastErrorCode_REFRESH_ACCESS_TOKEN
+	case errors.Is(err, ErrCannotSignIn) && statusErr.Code == codes.Unauthorized:
+		return LastErrorCode_REFRESH_ACCESS_TOKEN
+	}
+	return LastErrorCode_RESPONSE
+}
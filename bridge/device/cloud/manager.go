@@ -66,6 +66,7 @@ type Config struct {
 	AuthorizationProvider string
 	CloudID               string
 	CloudURL              string
+	Endpoints             []cloud.Endpoint
 }
 
 type CAPoolGetter = interface {
@@ -83,6 +84,9 @@ type Manager struct {
 	getCertificates GetCertificates
 	removeCloudCAs  RemoveCloudCAs
 	tickInterval    time.Duration
+	backoff         BackoffConfig
+
+	onConfigurationChanged func()
 
 	private struct {
 		mutex                     sync.Mutex
@@ -103,6 +107,9 @@ type Manager struct {
 	reconnect          atomic.Bool
 	trigger            chan bool
 	loop               *eventloop.Loop
+	timer              *time.Timer
+	// failures is the number of consecutive failed attempts to connect, it is accessed only by the event loop
+	failures int
 }
 
 func New(cfg Config, deviceID uuid.UUID, save func(), handler net.RequestHandler, getLinks GetLinksFilteredBy, caPool CAPoolGetter, loop *eventloop.Loop, opts ...Option) (*Manager, error) {
@@ -119,6 +126,9 @@ func New(cfg Config, deviceID uuid.UUID, save func(), handler net.RequestHandler
 		},
 		logger:       log.NewNilLogger(),
 		tickInterval: time.Second * 10,
+		onConfigurationChanged: func() {
+			// do nothing
+		},
 	}
 	for _, opt := range opts {
 		opt(&o)
@@ -138,6 +148,9 @@ func New(cfg Config, deviceID uuid.UUID, save func(), handler net.RequestHandler
 		logger:          o.logger,
 		loop:            loop,
 		tickInterval:    o.tickInterval,
+		backoff:         o.backoff.normalize(o.tickInterval),
+
+		onConfigurationChanged: o.onConfigurationChanged,
 	}
 	c.private.cfg.ProvisioningStatus = cloud.ProvisioningStatus_UNINITIALIZED
 	c.importConfig(cfg)
@@ -145,12 +158,12 @@ func New(cfg Config, deviceID uuid.UUID, save func(), handler net.RequestHandler
 }
 
 func (c *Manager) Get(request *net.Request) (*pool.Message, error) {
-	cfg := c.getCloudConfiguration()
+	cfg := c.GetCloudConfiguration()
 	return resources.CreateResponseContent(request.Context(), cfg, codes.Content)
 }
 
 func (c *Manager) ExportConfig() Config {
-	configuration := c.getCloudConfiguration()
+	configuration := c.GetCloudConfiguration()
 	creds := c.getCreds()
 	return Config{
 		CloudID:               configuration.CloudID,
@@ -160,6 +173,7 @@ func (c *Manager) ExportConfig() Config {
 		UserID:                creds.UserID,
 		RefreshToken:          creds.RefreshToken,
 		ValidUntil:            creds.ValidUntil,
+		Endpoints:             configuration.Endpoints,
 	}
 }
 
@@ -168,6 +182,7 @@ func (c *Manager) importConfig(cfg Config) {
 		AuthorizationProvider: cfg.AuthorizationProvider,
 		URL:                   cfg.CloudURL,
 		CloudID:               cfg.CloudID,
+		Endpoints:             cfg.Endpoints,
 	})
 	c.setCreds(ocfCloud.CoapSignUpResponse{
 		AccessToken:  cfg.AccessToken,
@@ -178,7 +193,7 @@ func (c *Manager) importConfig(cfg Config) {
 }
 
 func (c *Manager) isInitialized() bool {
-	cfg := c.getCloudConfiguration()
+	cfg := c.GetCloudConfiguration()
 	return cfg.URL != ""
 }
 
@@ -196,8 +211,10 @@ func (c *Manager) handleTrigger(value reflect.Value, closed bool) {
 	wantToReset := value.Bool()
 	if wantToReset {
 		c.resetCredentials(ctx, true)
+		c.resetFailures()
 	}
 	if c.reconnect.CompareAndSwap(true, false) {
+		c.resetFailures()
 		err := c.close()
 		if err != nil && !errors.Is(err, context.Canceled) {
 			c.logger.Errorf("cannot close connection for reconnect: %w", err)
@@ -213,40 +230,83 @@ func (c *Manager) handleTrigger(value reflect.Value, closed bool) {
 		// resources will be published after sign in
 		c.resetPublishing()
 	}
-	if err := c.connect(ctx); err != nil {
-		c.logger.Errorf("cannot connect to cloud: %w", err)
-	} else {
-		c.setProvisioningStatus(cloud.ProvisioningStatus_REGISTERED)
+	if c.failures > 0 {
+		// the next attempt is scheduled by the backoff
+		return
 	}
+	c.handleConnect(ctx)
 }
 
 func (c *Manager) handleTimer(_ reflect.Value, closed bool) {
 	if closed {
 		return
 	}
-	if c.getCloudConfiguration().URL == "" {
+	if c.GetCloudConfiguration().URL == "" || c.isFailed() {
+		c.resetTimer(c.tickInterval)
 		return
 	}
-	if err := c.connect(context.Background()); err != nil {
-		c.logger.Errorf("cannot connect to cloud: %w", err)
-	} else {
+	c.handleConnect(context.Background())
+}
+
+func (c *Manager) resetTimer(d time.Duration) {
+	if c.timer != nil {
+		c.timer.Reset(d)
+	}
+}
+
+func (c *Manager) resetFailures() {
+	c.failures = 0
+	if c.isFailed() {
+		c.setProvisioningStatus(cloud.ProvisioningStatus_READY_TO_REGISTER)
+	}
+	c.resetTimer(c.tickInterval)
+}
+
+func (c *Manager) isFailed() bool {
+	return c.GetCloudConfiguration().ProvisioningStatus == cloud.ProvisioningStatus_FAILED
+}
+
+// handleConnect connects to the cloud and schedules the next attempt, the delay grows with each consecutive failure.
+func (c *Manager) handleConnect(ctx context.Context) {
+	err := c.connect(ctx)
+	c.setLastErrorCode(toLastErrorCode(err))
+	if err == nil {
+		c.failures = 0
 		c.setProvisioningStatus(cloud.ProvisioningStatus_REGISTERED)
+		c.resetTimer(c.tickInterval)
+		return
+	}
+	if !c.isInitialized() {
+		// configuration has been removed, e.g. the device has been unregistered by the cloud
+		c.logger.Errorf("cannot connect to cloud: %w", err)
+		c.resetFailures()
+		return
+	}
+	c.failures++
+	if c.backoff.MaxRetries > 0 && c.failures >= c.backoff.MaxRetries {
+		c.logger.Errorf("cannot connect to cloud, giving up after %v attempts: %w", c.failures, err)
+		c.setProvisioningStatus(cloud.ProvisioningStatus_FAILED)
+		c.resetTimer(c.tickInterval)
+		return
 	}
+	delay := c.backoff.delay(c.failures)
+	c.logger.Errorf("cannot connect to cloud, next attempt in %v: %w", delay, err)
+	c.resetTimer(delay)
 }
 
 func (c *Manager) Init() {
 	if c.private.cfg.URL != "" {
 		c.triggerRunner(false)
 	}
-	t := time.NewTicker(c.tickInterval)
+	c.timer = time.NewTimer(c.tickInterval)
 	handlers := []eventloop.Handler{
 		eventloop.NewReadHandler(reflect.ValueOf(c.trigger), c.handleTrigger),
-		eventloop.NewReadHandler(reflect.ValueOf(t.C), c.handleTimer),
+		eventloop.NewReadHandler(reflect.ValueOf(c.timer.C), c.handleTimer),
 		eventloop.NewReadHandler(reflect.ValueOf(c.done), func(_ reflect.Value, _ bool) {
 			_ = c.close()
 			// cleanup resources
-			c.loop.RemoveByChannels(reflect.ValueOf(c.done), reflect.ValueOf(t.C), reflect.ValueOf(c.trigger))
-			t.Stop()
+			c.loop.RemoveByChannels(reflect.ValueOf(c.done), reflect.ValueOf(c.timer.C), reflect.ValueOf(c.trigger))
+			c.timer.Stop()
 		}),
 	}
 	c.loop.Add(handlers...)
@@ -314,7 +374,7 @@ func (c *Manager) Post(request *net.Request) (*pool.Message, error) {
 		c.setCloudConfiguration(cfg)
 	}
 	c.triggerRunner(true)
-	currentCfg := c.getCloudConfiguration()
+	currentCfg := c.GetCloudConfiguration()
 	return resources.CreateResponseContent(request.Context(), currentCfg, codes.Changed)
 }
 
@@ -331,13 +391,21 @@ func (c *Manager) removePreviousCloudIDs() {
 }
 
 func (c *Manager) setCloudConfiguration(cfg cloud.ConfigurationUpdateRequest) {
+	defer c.onConfigurationChanged()
 	c.private.mutex.Lock()
 	defer c.private.mutex.Unlock()
 	c.private.previousCloudIDs = append(c.private.previousCloudIDs, c.private.cfg.CloudID)
+	for _, ep := range c.private.cfg.Endpoints {
+		if ep.ID != "" && ep.ID != c.private.cfg.CloudID {
+			c.private.previousCloudIDs = append(c.private.previousCloudIDs, ep.ID)
+		}
+	}
 	c.private.cfg.AuthorizationProvider = cfg.AuthorizationProvider
 	c.private.cfg.CloudID = cfg.CloudID
 	c.private.cfg.URL = cfg.URL
 	c.private.cfg.AuthorizationCode = cfg.AuthorizationCode
+	c.private.cfg.Endpoints = makeEndpoints(cfg)
+	c.private.cfg.LastErrorCode = LastErrorCode_OK
 	if cfg.URL == "" {
 		c.private.cfg.ProvisioningStatus = cloud.ProvisioningStatus_UNINITIALIZED
 		c.private.readyToPublishResources = nil
@@ -349,11 +417,26 @@ func (c *Manager) setCloudConfiguration(cfg cloud.ConfigurationUpdateRequest) {
 
 func (c *Manager) setProvisioningStatus(status cloud.ProvisioningStatus) {
 	c.private.mutex.Lock()
-	defer c.private.mutex.Unlock()
+	changed := c.private.cfg.ProvisioningStatus != status
 	c.private.cfg.ProvisioningStatus = status
+	c.private.mutex.Unlock()
+	if changed {
+		c.onConfigurationChanged()
+	}
+}
+
+func (c *Manager) setLastErrorCode(code int) {
+	c.private.mutex.Lock()
+	changed := c.private.cfg.LastErrorCode != code
+	c.private.cfg.LastErrorCode = code
+	c.private.mutex.Unlock()
+	if changed {
+		c.onConfigurationChanged()
+	}
 }
 
-func (c *Manager) getCloudConfiguration() Configuration {
+// GetCloudConfiguration returns the actual cloud configuration with the provisioning status and the last error code.
+func (c *Manager) GetCloudConfiguration() Configuration {
 	c.private.mutex.Lock()
 	defer c.private.mutex.Unlock()
 	return c.private.cfg
@@ -502,7 +585,7 @@ func (c *Manager) dial(ctx context.Context) error {
 		return nil
 	}
 	_ = c.close()
-	cfg := c.getCloudConfiguration()
+	cfg := c.GetCloudConfiguration()
 
 	caPool, err := c.caPool.GetPool()
 	if err != nil {
@@ -513,11 +596,14 @@ func (c *Manager) dial(ctx context.Context) error {
 		MinVersion:         tls.VersionTLS12,
 		Certificates:       c.getCertificates(c.deviceID.String()),
 		VerifyPeerCertificate: coap.NewVerifyPeerCertificate(caPool, func(cert *x509.Certificate) error {
-			cloudID, errP := uuid.Parse(c.getCloudConfiguration().CloudID)
+			cloudID, errP := uuid.Parse(c.GetCloudConfiguration().CloudID)
 			if errP != nil {
-				return fmt.Errorf("cannot parse cloudID: %w", errP)
+				return fmt.Errorf("%w: cannot parse cloudID: %w", ErrInvalidCloudCertificate, errP)
+			}
+			if errV := coap.VerifyCloudCertificate(cert, cloudID); errV != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidCloudCertificate, errV)
 			}
-			return coap.VerifyCloudCertificate(cert, cloudID)
+			return nil
 		}),
 	}
 
@@ -526,7 +612,9 @@ func (c *Manager) dial(ctx context.Context) error {
 	}
 	addr, err := ep.GetAddr()
 	if err != nil {
-		return fmt.Errorf("cannot get address from %v: %w", ep, err)
+		err = fmt.Errorf("cannot get address from %v: %w", ep, err)
+		c.failover(err)
+		return err
 	}
 	m := mux.NewRouter()
 	m.Use(net.CreateLoggingMiddleware(c.logger))
@@ -547,7 +635,9 @@ func (c *Manager) dial(ctx context.Context) error {
 			}
 		}))
 	if err != nil {
-		return fmt.Errorf("cannot dial to %v: %w", addr.String(), err)
+		err = fmt.Errorf("cannot dial to %v: %w", addr.String(), err)
+		c.failover(err)
+		return err
 	}
 	conn.AddOnClose(func() {
 		c.private.mutex.Lock()
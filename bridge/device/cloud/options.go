@@ -25,11 +25,13 @@ import (
 )
 
 type OptionsCfg struct {
-	maxMessageSize  uint32
-	getCertificates GetCertificates
-	removeCloudCAs  RemoveCloudCAs
-	logger          log.Logger
-	tickInterval    time.Duration
+	maxMessageSize         uint32
+	getCertificates        GetCertificates
+	removeCloudCAs         RemoveCloudCAs
+	logger                 log.Logger
+	tickInterval           time.Duration
+	backoff                BackoffConfig
+	onConfigurationChanged func()
 }
 
 type Option func(*OptionsCfg)
@@ -65,3 +67,20 @@ func WithTickInterval(t time.Duration) Option {
 		o.tickInterval = t
 	}
 }
+
+// WithBackoff configures the delays between the failed attempts to connect to the cloud.
+func WithBackoff(backoff BackoffConfig) Option {
+	return func(o *OptionsCfg) {
+		o.backoff = backoff
+	}
+}
+
+// WithOnConfigurationChanged sets the callback invoked when the cloud configuration, the provisioning status or the
+// last error code changes.
+func WithOnConfigurationChanged(f func()) Option {
+	return func(o *OptionsCfg) {
+		if f != nil {
+			o.onConfigurationChanged = f
+		}
+	}
+}
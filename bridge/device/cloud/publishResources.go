@@ -87,7 +87,7 @@ func (c *Manager) publishResources(ctx context.Context) error {
 		return errCannotPublishResources(err)
 	}
 	if resp.Code() != codes.Changed {
-		return errCannotPublishResources(fmt.Errorf("unexpected status code %v", resp.Code()))
+		return errCannotPublishResources(StatusCodeError{Code: resp.Code()})
 	}
 	c.resourcesPublished = true
 	c.logger.Infof("resources published")
@@ -139,7 +139,7 @@ func (c *Manager) unpublishResources(ctx context.Context) error {
 			return errCannotUnpublishResources(err)
 		}
 		if resp.Code() != codes.Deleted {
-			return errCannotUnpublishResources(fmt.Errorf("unexpected status code %v", resp.Code()))
+			return errCannotUnpublishResources(StatusCodeError{Code: resp.Code()})
 		}
 	}
 	c.logger.Infof("resources unpublished")
@@ -61,7 +61,7 @@ func (c *Manager) refreshToken(ctx context.Context) error {
 		if resp.Code() == codes.Unauthorized {
 			c.cleanup()
 		}
-		return errCannotRefreshToken(fmt.Errorf("unexpected status code %v", resp.Code()))
+		return errCannotRefreshToken(StatusCodeError{Code: resp.Code()})
 	}
 	var refreshResp ocfCloud.CoapRefreshTokenResponse
 	if err = cbor.ReadFrom(resp.Body(), &refreshResp); err != nil {
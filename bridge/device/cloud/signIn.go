@@ -70,17 +70,22 @@ func (c *Manager) signIn(ctx context.Context) error {
 	c.setProvisioningStatus(cloud.ProvisioningStatus_REGISTERING)
 	resp, err := client.Do(req)
 	if err != nil {
-		return errCannotSignIn(err)
+		err = errCannotSignIn(err)
+		c.failover(err)
+		return err
 	}
 	if resp.Code() != codes.Changed {
-		if resp.Code() == codes.Unauthorized {
-			if creds.RefreshToken == "" {
-				c.cleanup()
-			} else {
-				c.forceRefreshToken = true
-			}
+		err = errCannotSignIn(StatusCodeError{Code: resp.Code()})
+		if resp.Code() != codes.Unauthorized {
+			c.failover(err)
+			return err
+		}
+		if creds.RefreshToken == "" {
+			c.cleanup()
+		} else {
+			c.forceRefreshToken = true
 		}
-		return errCannotSignIn(fmt.Errorf("unexpected status code %v", resp.Code()))
+		return err
 	}
 	var signInResp ocfCloud.CoapSignInResponse
 	err = cbor.ReadFrom(resp.Body(), &signInResp)
@@ -70,7 +70,7 @@ func (c *Manager) signOff(ctx context.Context) error {
 		return errCannotSignOff(err)
 	}
 	if resp.Code() != codes.Deleted {
-		return errCannotSignOff(fmt.Errorf("unexpected status code %v", resp.Code()))
+		return errCannotSignOff(StatusCodeError{Code: resp.Code()})
 	}
 	c.logger.Infof("signed off")
 	return nil
@@ -59,7 +59,7 @@ func (c *Manager) signUp(ctx context.Context) error {
 	if creds.AccessToken != "" {
 		return nil
 	}
-	cfg := c.getCloudConfiguration()
+	cfg := c.GetCloudConfiguration()
 	signUpRequest, err := MakeSignUpRequest(c.deviceID.String(), cfg.AuthorizationCode, cfg.AuthorizationProvider)
 	if err != nil {
 		return errCannotSignUp(err)
@@ -78,7 +78,7 @@ func (c *Manager) signUp(ctx context.Context) error {
 		return errCannotSignUp(err)
 	}
 	if resp.Code() != codes.Changed {
-		return errCannotSignUp(fmt.Errorf("unexpected status code %v", resp.Code()))
+		return errCannotSignUp(StatusCodeError{Code: resp.Code()})
 	}
 	var signUpResp ocfCloud.CoapSignUpResponse
 	err = cbor.ReadFrom(resp.Body(), &signUpResp)
@@ -20,10 +20,12 @@ package device
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/plgd-dev/device/v2/bridge/device/cloud"
 	"github.com/plgd-dev/device/v2/bridge/device/credential"
+	"github.com/plgd-dev/device/v2/bridge/device/security"
 )
 
 type CloudConfig struct {
@@ -36,6 +38,12 @@ type CredentialConfig struct {
 	credential.Config
 }
 
+// SecurityConfig enables the ownership transfer and the access control of the device, it requires the credential resource.
+type SecurityConfig struct {
+	Enabled bool
+	security.Config
+}
+
 type Config struct {
 	ID                    uuid.UUID
 	Name                  string
@@ -44,6 +52,7 @@ type Config struct {
 	MaxMessageSize        uint32
 	Cloud                 CloudConfig
 	Credential            CredentialConfig
+	Security              SecurityConfig
 }
 
 func (cfg *Config) Validate() error {
@@ -58,5 +67,14 @@ func (cfg *Config) Validate() error {
 		cfg.Name = "Unnamed"
 	}
 
+	if cfg.Security.Enabled {
+		if !cfg.Credential.Enabled {
+			return errors.New("security requires enabled credential")
+		}
+		if err := cfg.Security.Validate(); err != nil {
+			return fmt.Errorf("security.%w", err)
+		}
+	}
+
 	return nil
 }
@@ -23,6 +23,8 @@ import (
 
 	"github.com/google/uuid"
 	bridgeDevice "github.com/plgd-dev/device/v2/bridge/device"
+	"github.com/plgd-dev/device/v2/bridge/device/security"
+	"github.com/plgd-dev/device/v2/schema/doxm"
 	"github.com/stretchr/testify/require"
 )
 
@@ -53,6 +55,41 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "Valid security configuration",
+			cfg: bridgeDevice.Config{
+				ProtocolIndependentID: uuid.New(),
+				Credential:            bridgeDevice.CredentialConfig{Enabled: true},
+				Security: bridgeDevice.SecurityConfig{
+					Enabled: true,
+					Config: security.Config{
+						OwnerTransferMethods: []doxm.OwnerTransferMethod{doxm.JustWorks, doxm.ManufacturerCertificate},
+					},
+				},
+			},
+		},
+		{
+			name: "Security without credential",
+			cfg: bridgeDevice.Config{
+				ProtocolIndependentID: uuid.New(),
+				Security:              bridgeDevice.SecurityConfig{Enabled: true},
+			},
+			wantError: true,
+		},
+		{
+			name: "Security with unsupported owner transfer method",
+			cfg: bridgeDevice.Config{
+				ProtocolIndependentID: uuid.New(),
+				Credential:            bridgeDevice.CredentialConfig{Enabled: true},
+				Security: bridgeDevice.SecurityConfig{
+					Enabled: true,
+					Config: security.Config{
+						OwnerTransferMethods: []doxm.OwnerTransferMethod{doxm.SharedPin},
+					},
+				},
+			},
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -21,9 +21,9 @@ package credential
 import (
 	"crypto/x509"
 
+	fxcbor "github.com/fxamacker/cbor/v2"
 	"github.com/plgd-dev/device/v2/bridge/net"
 	"github.com/plgd-dev/device/v2/bridge/resources"
-	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
 	pkgX509 "github.com/plgd-dev/device/v2/pkg/security/x509"
 	"github.com/plgd-dev/device/v2/schema/credential"
 	"github.com/plgd-dev/device/v2/schema/interfaces"
@@ -32,6 +32,16 @@ import (
 	"github.com/plgd-dev/go-coap/v3/pkg/sync"
 )
 
+// updateDecMode decodes the credential updates. The clients send the pre-shared keys as raw bytes in the text
+// strings, so the invalid UTF-8 is accepted.
+var updateDecMode = func() fxcbor.DecMode {
+	dm, err := fxcbor.DecOptions{UTF8: fxcbor.UTF8DecodeInvalid}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return dm
+}()
+
 type Manager struct {
 	credentials *sync.Map[int, credential.Credential]
 	save        func()
@@ -74,6 +84,19 @@ func (m *Manager) GetCAPool() []*x509.Certificate {
 	return certs
 }
 
+// GetPreSharedKey returns the pre-shared key of the symmetric pair-wise credential for the subject.
+func (m *Manager) GetPreSharedKey(subject string) ([]byte, bool) {
+	var psk []byte
+	m.credentials.Range(func(_ int, value credential.Credential) bool {
+		if value.Type != credential.CredentialType_SYMMETRIC_PAIR_WISE || value.Subject != subject || value.PrivateData == nil {
+			return true
+		}
+		psk = value.PrivateData.Data()
+		return len(psk) == 0
+	})
+	return psk, len(psk) > 0
+}
+
 func (m *Manager) getNextID() int {
 	var id int
 	m.credentials.Range(func(key int, _ credential.Credential) bool {
@@ -159,7 +182,7 @@ func (m *Manager) Get(request *net.Request) (*pool.Message, error) {
 
 func (m *Manager) Post(request *net.Request) (*pool.Message, error) {
 	var cfg credential.CredentialUpdateRequest
-	err := cbor.ReadFrom(request.Body(), &cfg)
+	err := updateDecMode.NewDecoder(request.Body()).Decode(&cfg)
 	if err != nil {
 		return resources.CreateResponseBadRequest(request.Context(), err)
 	}
@@ -198,6 +198,35 @@ func TestClearCredentials(t *testing.T) {
 	require.Equal(t, 0, m.credentials.Length())
 }
 
+func TestGetPreSharedKey(t *testing.T) {
+	m := New(Config{}, func() {})
+
+	m.AddOrReplaceCredentials(credential.Credential{
+		Subject: "owner",
+		Type:    credential.CredentialType_SYMMETRIC_PAIR_WISE,
+		PrivateData: &credential.CredentialPrivateData{
+			DataInternal: "0123456789abcdef",
+			Encoding:     credential.CredentialPrivateDataEncoding_RAW,
+		},
+	}, credential.Credential{
+		Subject: "other",
+		Type:    credential.CredentialType_PIN_OR_PASSWORD,
+		PrivateData: &credential.CredentialPrivateData{
+			DataInternal: "password",
+			Encoding:     credential.CredentialPrivateDataEncoding_RAW,
+		},
+	})
+
+	psk, ok := m.GetPreSharedKey("owner")
+	require.True(t, ok)
+	require.Equal(t, []byte("0123456789abcdef"), psk)
+
+	_, ok = m.GetPreSharedKey("other")
+	require.False(t, ok)
+	_, ok = m.GetPreSharedKey("unknown")
+	require.False(t, ok)
+}
+
 func TestGetCredential(t *testing.T) {
 	m := New(Config{}, func() {}) // Create an instance of the Manager struct
 
@@ -283,6 +312,41 @@ func TestPostCredential(t *testing.T) {
 	require.Equal(t, "subject1", creds.Credentials[0].Subject)
 }
 
+func TestPostPreSharedKeyCredential(t *testing.T) {
+	m := New(Config{}, func() {})
+
+	msg := pool.NewMessage(context.Background())
+	msg.SetCode(codes.POST)
+	err := msg.SetPath(credential.ResourceURI)
+	require.NoError(t, err)
+	msg.SetToken([]byte{0x01})
+
+	// the clients send the raw pre-shared key as a text string, which is not valid UTF-8
+	psk := []byte{0xff, 0xfe, 0x01, 0x80, 0x00, 0xc3, 0x28, 0xa0, 0xa1, 0xe2, 0x28, 0xa1, 0xf0, 0x28, 0x8c, 0xbc}
+	body, err := cbor.Encode(credential.CredentialUpdateRequest{
+		Credentials: []credential.Credential{
+			{
+				Subject: "owner",
+				Type:    credential.CredentialType_SYMMETRIC_PAIR_WISE,
+				PrivateData: &credential.CredentialPrivateData{
+					DataInternal: string(psk),
+					Encoding:     credential.CredentialPrivateDataEncoding_RAW,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	msg.SetBody(bytes.NewReader(body))
+
+	resp, err := m.Post(&net.Request{Message: msg})
+	require.NoError(t, err)
+	require.Equal(t, codes.Changed, resp.Code())
+
+	got, ok := m.GetPreSharedKey("owner")
+	require.True(t, ok)
+	require.Equal(t, psk, got)
+}
+
 func TestExportConfig(t *testing.T) {
 	m := New(Config{}, func() {}) // Create an instance of the Manager struct
 
@@ -21,6 +21,7 @@ package device
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync/atomic"
@@ -28,6 +29,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/plgd-dev/device/v2/bridge/device/cloud"
 	"github.com/plgd-dev/device/v2/bridge/device/credential"
+	"github.com/plgd-dev/device/v2/bridge/device/security"
 	"github.com/plgd-dev/device/v2/bridge/device/thingDescription"
 	"github.com/plgd-dev/device/v2/bridge/net"
 	"github.com/plgd-dev/device/v2/bridge/resources"
@@ -35,15 +37,23 @@ import (
 	resourcesDevice "github.com/plgd-dev/device/v2/bridge/resources/device"
 	"github.com/plgd-dev/device/v2/bridge/resources/discovery"
 	"github.com/plgd-dev/device/v2/bridge/resources/maintenance"
+	aclResource "github.com/plgd-dev/device/v2/bridge/resources/secure/acl"
 	credentialResource "github.com/plgd-dev/device/v2/bridge/resources/secure/credential"
+	csrResource "github.com/plgd-dev/device/v2/bridge/resources/secure/csr"
+	doxmResource "github.com/plgd-dev/device/v2/bridge/resources/secure/doxm"
+	pstatResource "github.com/plgd-dev/device/v2/bridge/resources/secure/pstat"
 	thingDescriptionResource "github.com/plgd-dev/device/v2/bridge/resources/thingDescription"
 	"github.com/plgd-dev/device/v2/pkg/eventloop"
 	pkgLog "github.com/plgd-dev/device/v2/pkg/log"
 	"github.com/plgd-dev/device/v2/schema"
+	aclSchema "github.com/plgd-dev/device/v2/schema/acl"
 	cloudSchema "github.com/plgd-dev/device/v2/schema/cloud"
 	credentialSchema "github.com/plgd-dev/device/v2/schema/credential"
+	csrSchema "github.com/plgd-dev/device/v2/schema/csr"
 	plgdDevice "github.com/plgd-dev/device/v2/schema/device"
+	doxmSchema "github.com/plgd-dev/device/v2/schema/doxm"
 	maintenanceSchema "github.com/plgd-dev/device/v2/schema/maintenance"
+	pstatSchema "github.com/plgd-dev/device/v2/schema/pstat"
 	plgdResources "github.com/plgd-dev/device/v2/schema/resources"
 	"github.com/plgd-dev/go-coap/v3/message"
 	"github.com/plgd-dev/go-coap/v3/message/codes"
@@ -70,6 +80,7 @@ type Device struct {
 	resources               *sync.Map[string, Resource]
 	cloudManager            *cloud.Manager
 	credentialManager       *credential.Manager
+	securityManager         *security.Manager
 	thingDescriptionManager *thingDescription.Manager
 	onDeviceUpdated         func(d *Device)
 	loop                    *eventloop.Loop
@@ -119,6 +130,11 @@ func (d *Device) ExportConfig() Config {
 	} else {
 		cfg.Credential.Enabled = false
 	}
+	if d.securityManager != nil {
+		cfg.Security.Config = d.securityManager.ExportConfig()
+	} else {
+		cfg.Security.Enabled = false
+	}
 	return cfg
 }
 
@@ -149,9 +165,20 @@ func New(cfg Config, opts ...Option) (*Device, error) {
 		d.done = make(chan struct{})
 	}
 
+	if cfg.Security.Enabled && !cfg.Credential.Enabled {
+		return nil, errors.New("cannot create security manager: credential is not enabled")
+	}
+
+	var cloudRes *cloudResource.Resource
 	cloudOpts := []cloud.Option{
 		cloud.WithMaxMessageSize(cfg.MaxMessageSize),
 		cloud.WithLogger(o.logger),
+		cloud.WithOnConfigurationChanged(func() {
+			// cps and clec are part of the representation of the cloud configuration resource
+			if cloudRes != nil {
+				cloudRes.NotifyObservers()
+			}
+		}),
 	}
 	if cfg.Credential.Enabled {
 		d.credentialManager = credential.New(cfg.Credential.Config, func() {
@@ -161,6 +188,21 @@ func New(cfg Config, opts ...Option) (*Device, error) {
 		o.caPool = credential.MakeCAPool(o.caPool, d.credentialManager.GetCAPool)
 		cloudOpts = append(cloudOpts, cloud.WithRemoveCloudCAs(d.credentialManager.RemoveCredentialsBySubjects))
 	}
+	if cfg.Security.Enabled {
+		sm, err := security.New(cfg.Security.Config, cfg.ID, func() {
+			d.onDeviceUpdated(d)
+		}, security.WithOnReset(d.onSecurityReset), security.WithLogger(o.logger), security.WithCredentials(d.credentialManager))
+		if err != nil {
+			return nil, fmt.Errorf("cannot create security manager: %w", err)
+		}
+		d.securityManager = sm
+		d.AddResources(
+			doxmResource.New(doxmSchema.ResourceURI, d.securityManager),
+			pstatResource.New(pstatSchema.ResourceURI, d.securityManager),
+			aclResource.New(aclSchema.ResourceURI, d.securityManager),
+			csrResource.New(csrSchema.ResourceURI, d.securityManager),
+		)
+	}
 	if cfg.Cloud.Enabled {
 		if o.getCertificates != nil {
 			cloudOpts = append(cloudOpts, cloud.WithGetCertificates(o.getCertificates))
@@ -168,12 +210,14 @@ func New(cfg Config, opts ...Option) (*Device, error) {
 		cloudOpts = append(cloudOpts, o.cloudOptions...)
 		cm, err := cloud.New(cfg.Cloud.Config, d.cfg.ID, func() {
 			d.onDeviceUpdated(d)
-		}, d.HandleRequest, d.GetLinksFilteredBy, o.caPool, o.loop, cloudOpts...)
+		}, d.handleRequest, d.GetLinksFilteredBy, o.caPool, o.loop, cloudOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("cannot create cloud manager: %w", err)
 		}
 		d.cloudManager = cm
-		d.AddResources(cloudResource.New(cloudSchema.ResourceURI, d.cloudManager))
+		cloudRes = cloudResource.New(cloudSchema.ResourceURI, d.cloudManager)
+		cloudRes.SetObserveHandler(o.loop, cloudRes.CreateSubscription)
+		d.AddResources(cloudRes)
 	}
 	if o.getThingDescription != nil {
 		td := thingDescription.New(d, o.loop)
@@ -187,11 +231,16 @@ func New(cfg Config, opts ...Option) (*Device, error) {
 
 	d.AddResources(resourcesDevice.New(plgdDevice.ResourceURI, d, o.getAdditionalProperties))
 	// oic/res is not discoverable
-	discoverResource := discovery.New(plgdResources.ResourceURI, d.GetLinks)
+	discoverResource := discovery.New(plgdResources.ResourceURI, d.GetLinks, d.getBatchItems)
 	discoverResource.PolicyBitMask = schema.Discoverable
 	d.AddResources(discoverResource)
 
 	d.AddResources(maintenance.New(maintenanceSchema.ResourceURI, func() {
+		if d.securityManager != nil {
+			// unregisters the device from the cloud too
+			d.securityManager.Reset()
+			return
+		}
 		if d.cloudManager != nil {
 			d.cloudManager.Unregister()
 		}
@@ -226,6 +275,28 @@ func (d *Device) GetCloudManager() *cloud.Manager {
 	return d.cloudManager
 }
 
+// GetSecurityManager returns security manager of the device, it is nil when the security is not enabled.
+func (d *Device) GetSecurityManager() *security.Manager {
+	return d.securityManager
+}
+
+func (d *Device) onSecurityReset() {
+	if d.credentialManager != nil {
+		d.credentialManager.ClearCredentials()
+	}
+	if d.cloudManager != nil {
+		d.cloudManager.Unregister()
+	}
+}
+
+// GetPreSharedKey returns the pre-shared key of the owner for the PSK identity used in the DTLS handshake.
+func (d *Device) GetPreSharedKey(identity []byte) ([]byte, bool) {
+	if d.credentialManager == nil {
+		return nil, false
+	}
+	return d.credentialManager.GetPreSharedKey(net.PreSharedKeySubject(identity))
+}
+
 // GetThingDescriptionManager returns thing description manager of the device.
 func (d *Device) GetThingDescriptionManager() *thingDescription.Manager {
 	return d.thingDescriptionManager
@@ -282,6 +353,32 @@ func (d *Device) GetLinks(request *net.Request) (links schema.ResourceLinks) {
 	return d.GetLinksFilteredBy(request.Endpoints, request.DeviceID(), request.ResourceTypes(), 0)
 }
 
+// getBatchItems returns the discoverable resources for the batch interface of the discovery resource, the security
+// resources are excluded because they are not readable by the batch request. For the access controlled requests,
+// only the resources readable by the peer are returned.
+func (d *Device) getBatchItems(request *net.Request) []resources.BatchItem {
+	di := request.DeviceID()
+	if di != uuid.Nil && di != d.GetID() {
+		return nil
+	}
+	resourceTypesFilter := request.ResourceTypes()
+	items := make([]resources.BatchItem, 0, d.resources.Length())
+	d.resources.Range(func(key string, resource Resource) bool {
+		if key == plgdResources.ResourceURI || security.IsSecurityResource(key) || resource.GetPolicyBitMask()&schema.Discoverable == 0 {
+			return true
+		}
+		if len(resourceTypesFilter) > 0 && !hasResourceTypes(resource.GetResourceTypes(), resourceTypesFilter) {
+			return true
+		}
+		if !resources.HasBatchAccess(request, key) {
+			return true
+		}
+		items = append(items, resource)
+		return true
+	})
+	return items
+}
+
 // LoadAndDeleteResource resource need to be closed after usage and also unpublished from the cloud
 func (d *Device) LoadAndDeleteResource(resourceHref string) (Resource, bool) {
 	return d.resources.LoadAndDelete(resourceHref)
@@ -308,7 +405,30 @@ func createResponseNotFound(ctx context.Context, uri string, token message.Token
 	return msg
 }
 
+func createResponseUnauthorized(ctx context.Context, uri string, token message.Token) *pool.Message {
+	msg := pool.NewMessage(ctx)
+	msg.SetCode(codes.Unauthorized)
+	msg.SetToken(token)
+	msg.SetBody(bytes.NewReader([]byte(fmt.Sprintf("access to uri %v denied", uri))))
+	return msg
+}
+
+// HandleRequest handles the request from the local network, when the security is enabled the access is checked by the access control list.
 func (d *Device) HandleRequest(req *net.Request) (*pool.Message, error) {
+	if d.securityManager != nil {
+		if !d.securityManager.HasAccess(req) {
+			return createResponseUnauthorized(req.Context(), req.URIPath(), req.Token()), nil
+		}
+		// the resources read by the batch interface are checked too
+		req.SetContext(resources.WithBatchAccessCheck(req.Context(), func(peer *net.PeerIdentity, href string) bool {
+			return d.securityManager.HasAccessToResource(peer, href, codes.GET)
+		}))
+	}
+	return d.handleRequest(req)
+}
+
+// handleRequest handles the request without the access control, it is used for the requests from the cloud.
+func (d *Device) handleRequest(req *net.Request) (*pool.Message, error) {
 	uri := req.URIPath()
 	res, ok := d.resources.Load(uri)
 	if !ok {
@@ -19,18 +19,29 @@
 package device_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/plgd-dev/device/v2/bridge/device"
 	"github.com/plgd-dev/device/v2/bridge/device/cloud"
+	"github.com/plgd-dev/device/v2/bridge/device/security"
+	"github.com/plgd-dev/device/v2/bridge/net"
 	"github.com/plgd-dev/device/v2/bridge/resources"
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	aclSchema "github.com/plgd-dev/device/v2/schema/acl"
 	cloudSchema "github.com/plgd-dev/device/v2/schema/cloud"
+	credentialSchema "github.com/plgd-dev/device/v2/schema/credential"
+	csrSchema "github.com/plgd-dev/device/v2/schema/csr"
 	plgdDevice "github.com/plgd-dev/device/v2/schema/device"
+	doxmSchema "github.com/plgd-dev/device/v2/schema/doxm"
 	"github.com/plgd-dev/device/v2/schema/interfaces"
 	maintenanceSchema "github.com/plgd-dev/device/v2/schema/maintenance"
+	pstatSchema "github.com/plgd-dev/device/v2/schema/pstat"
 	plgdResources "github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
 	"github.com/stretchr/testify/require"
 )
 
@@ -79,9 +90,139 @@ func TestNewDeviceWithCloud(t *testing.T) {
 	dev, err := device.New(cfg, device.WithCAPool(cloud.MakeCAPool(nil, true)))
 	require.NoError(t, err)
 	cfg.ResourceTypes = append(cfg.ResourceTypes, "oic.wk.d")
+	// cloud url is the only endpoint
+	cfg.Cloud.Endpoints = []cloudSchema.Endpoint{{ID: cfg.Cloud.CloudID, URI: cfg.Cloud.CloudURL}}
 	require.Equal(t, cfg, dev.ExportConfig())
 }
 
+func TestNewDeviceWithSecurity(t *testing.T) {
+	cfg := deviceCfg
+	cfg.Security.Enabled = true
+	_, err := device.New(cfg)
+	require.Error(t, err)
+
+	cfg.Credential.Enabled = true
+	dev, err := device.New(cfg)
+	require.NoError(t, err)
+	for _, href := range []string{doxmSchema.ResourceURI, pstatSchema.ResourceURI, aclSchema.ResourceURI, csrSchema.ResourceURI} {
+		_, ok := dev.GetResource(href)
+		require.True(t, ok, href)
+	}
+	require.NotNil(t, dev.GetSecurityManager())
+	exported := dev.ExportConfig()
+	require.True(t, exported.Security.Enabled)
+	require.Equal(t, pstatSchema.OperationalState_RFOTM, exported.Security.OperationalState)
+
+	// only the ownership transfer method can be selected over the unsecure connection
+	msg := pool.NewMessage(context.Background())
+	msg.SetCode(codes.POST)
+	err = msg.SetPath(pstatSchema.ResourceURI)
+	require.NoError(t, err)
+	resp, err := dev.HandleRequest(&net.Request{Message: msg})
+	require.NoError(t, err)
+	require.Equal(t, codes.Unauthorized, resp.Code())
+}
+
+func TestDiscoveryBatch(t *testing.T) {
+	cfg := deviceCfg
+	cfg.Security.Enabled = true
+	cfg.Credential.Enabled = true
+	dev, err := device.New(cfg)
+	require.NoError(t, err)
+
+	msg := pool.NewMessage(context.Background())
+	msg.SetCode(codes.GET)
+	err = msg.SetPath(plgdResources.ResourceURI)
+	require.NoError(t, err)
+	msg.AddQuery("if=" + interfaces.OC_IF_B)
+	msg.AddQuery("di=" + cfg.ID.String())
+	// the discovery is allowed over the unsecure connection in RFOTM
+	resp, err := dev.HandleRequest(&net.Request{Message: msg})
+	require.NoError(t, err)
+	require.Equal(t, codes.Content, resp.Code())
+	var batch plgdResources.BatchResourceDiscovery
+	err = cbor.ReadFrom(resp.Body(), &batch)
+	require.NoError(t, err)
+	hrefs := make([]string, 0, len(batch))
+	for _, b := range batch {
+		require.Equal(t, cfg.ID.String(), b.DeviceID())
+		hrefs = append(hrefs, b.Href())
+	}
+	// discovery and security resources are not included, the other resources are not accessible in RFOTM
+	require.ElementsMatch(t, []string{plgdDevice.ResourceURI}, hrefs)
+}
+
+func TestDiscoveryBatchAccessControl(t *testing.T) {
+	ownerID := uuid.New()
+	cfg := deviceCfg
+	cfg.Security.Enabled = true
+	cfg.Security.Config = security.Config{
+		Owned:            true,
+		OwnerID:          ownerID.String(),
+		ResourceOwner:    ownerID.String(),
+		OperationalState: pstatSchema.OperationalState_RFNOP,
+		AccessControlList: []aclSchema.AccessControl{
+			{
+				ID:         1,
+				Permission: aclSchema.AllPermissions,
+				Subject:    aclSchema.Subject{Subject_Device: &aclSchema.Subject_Device{DeviceID: ownerID.String()}},
+				Resources:  aclSchema.AllResources,
+			},
+			{
+				ID:         2,
+				Permission: aclSchema.Permission_READ,
+				Subject:    aclSchema.Subject{Subject_Connection: &aclSchema.Subject_Connection{Type: aclSchema.ConnectionType_ANON_CLEAR}},
+				Resources: []aclSchema.Resource{
+					{Href: plgdDevice.ResourceURI, Interfaces: []string{"*"}},
+					{Href: plgdResources.ResourceURI, Interfaces: []string{"*"}},
+				},
+			},
+		},
+	}
+	cfg.Credential.Enabled = true
+	cfg.Credential.Config = credentialSchema.CredentialResponse{
+		ResourceOwner: ownerID.String(),
+		Credentials: []credentialSchema.Credential{
+			{
+				ID:      1,
+				Subject: ownerID.String(),
+				Type:    credentialSchema.CredentialType_SYMMETRIC_PAIR_WISE,
+				PrivateData: &credentialSchema.CredentialPrivateData{
+					DataInternal: []byte("0123456789abcdef"),
+					Encoding:     credentialSchema.CredentialPrivateDataEncoding_RAW,
+				},
+			},
+		},
+	}
+	dev, err := device.New(cfg)
+	require.NoError(t, err)
+
+	getBatch := func(peer *net.PeerIdentity) []string {
+		msg := pool.NewMessage(context.Background())
+		msg.SetCode(codes.GET)
+		err := msg.SetPath(plgdResources.ResourceURI)
+		require.NoError(t, err)
+		msg.AddQuery("if=" + interfaces.OC_IF_B)
+		resp, err := dev.HandleRequest(&net.Request{Message: msg, Peer: peer})
+		require.NoError(t, err)
+		require.Equal(t, codes.Content, resp.Code())
+		var batch plgdResources.BatchResourceDiscovery
+		err = cbor.ReadFrom(resp.Body(), &batch)
+		require.NoError(t, err)
+		hrefs := make([]string, 0, len(batch))
+		for _, b := range batch {
+			hrefs = append(hrefs, b.Href())
+		}
+		return hrefs
+	}
+
+	// the anonymous peer can read only the device resource
+	require.ElementsMatch(t, []string{plgdDevice.ResourceURI}, getBatch(nil))
+	ownerBin, err := ownerID.MarshalBinary()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{plgdDevice.ResourceURI, maintenanceSchema.ResourceURI}, getBatch(&net.PeerIdentity{PSKIdentity: ownerBin}))
+}
+
 func TestGetResource(t *testing.T) {
 	dev, err := device.New(deviceCfg)
 	require.NoError(t, err)
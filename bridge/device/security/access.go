@@ -0,0 +1,224 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package security
+
+import (
+	"crypto/x509"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/net"
+	pkgX509 "github.com/plgd-dev/device/v2/pkg/security/x509"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+)
+
+const securityResourcePrefix = "/oic/sec/"
+
+// IsSecurityResource returns true for the security virtual resources (/oic/sec/*), which are not matched by the ACE wildcards.
+func IsSecurityResource(href string) bool {
+	return strings.HasPrefix(href, securityResourcePrefix)
+}
+
+func toPermission(code codes.Code) acl.Permission {
+	switch code {
+	case codes.GET:
+		return acl.Permission_READ
+	case codes.PUT:
+		return acl.Permission_CREATE
+	case codes.DELETE:
+		return acl.Permission_DELETE
+	}
+	return acl.Permission_WRITE
+}
+
+// verifyCertificates verifies the certificate chain of the peer against the certificate authorities provisioned to the device.
+func (m *Manager) verifyCertificates(certs []*x509.Certificate) bool {
+	if m.credentials == nil {
+		return false
+	}
+	cas := m.credentials.GetCAPool()
+	if len(cas) == 0 {
+		return false
+	}
+	roots := x509.NewCertPool()
+	for _, ca := range cas {
+		roots.AddCert(ca)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+// hasPreSharedKey checks that the peer used the pre-shared key of the credential provisioned to this device. The key is
+// selected by the listener of this device, which doesn't route the requests to the other devices.
+func (m *Manager) hasPreSharedKey(peer *net.PeerIdentity) bool {
+	if m.credentials == nil || len(peer.PSKIdentity) == 0 {
+		return false
+	}
+	_, ok := m.credentials.GetPreSharedKey(net.PreSharedKeySubject(peer.PSKIdentity))
+	return ok
+}
+
+// isAuthenticated returns true when the peer presented a certificate chain issued by a provisioned certificate authority
+// or used a pre-shared key provisioned to the device. The anonymous secure connections are not authenticated.
+func (m *Manager) isAuthenticated(peer *net.PeerIdentity) bool {
+	if peer == nil {
+		return false
+	}
+	if len(peer.Certificates) > 0 {
+		return m.verifyCertificates(peer.Certificates)
+	}
+	return m.hasPreSharedKey(peer)
+}
+
+// hasRole checks the roles embedded in the leaf certificate of the authenticated peer, the roles asserted
+// by the /oic/sec/roles resource are not supported by the bridged devices.
+func hasRole(peer *net.PeerIdentity, role acl.Subject_Role) bool {
+	if len(peer.Certificates) == 0 {
+		return false
+	}
+	roles, err := pkgX509.ParseRoles(peer.Certificates[0])
+	if err != nil {
+		return false
+	}
+	for _, r := range roles {
+		if r.Role == role.Role && (role.Authority == "" || r.Authority == role.Authority) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSubject(subject acl.Subject, peer *net.PeerIdentity, authenticated bool) bool {
+	if subject.Subject_Connection != nil {
+		switch subject.Subject_Connection.Type {
+		case acl.ConnectionType_ANON_CLEAR:
+			return true
+		case acl.ConnectionType_AUTH_CRYPT:
+			return authenticated
+		}
+		return false
+	}
+	if peer == nil || !authenticated {
+		return false
+	}
+	if subject.Subject_Device != nil {
+		if subject.Subject_Device.DeviceID == "*" {
+			return true
+		}
+		peerID := peer.DeviceID()
+		return peerID != uuid.Nil && peerID.String() == subject.Subject_Device.DeviceID
+	}
+	if subject.Subject_Role != nil {
+		return hasRole(peer, *subject.Subject_Role)
+	}
+	return false
+}
+
+func matchResource(resources []acl.Resource, href string) bool {
+	for _, r := range resources {
+		if r.Href == href {
+			return true
+		}
+		if r.Wildcard != "" && !IsSecurityResource(href) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manager) isResourceOwnerLocked(peer *net.PeerIdentity, authenticated bool) bool {
+	if peer == nil || !authenticated {
+		return false
+	}
+	peerID := peer.DeviceID()
+	return peerID != uuid.Nil && peerID.String() == m.cfg.ResourceOwner
+}
+
+// isOwnerTransferSessionLocked returns true for the secure connection established by the selected ownership transfer
+// method. The just works method uses the anonymous cipher suite, the manufacturer certificate method the certificate of the client.
+func (m *Manager) isOwnerTransferSessionLocked(peer *net.PeerIdentity) bool {
+	if peer == nil {
+		return false
+	}
+	switch m.cfg.SelectedOwnerTransferMethod {
+	case doxm.JustWorks:
+		return len(peer.Certificates) == 0 && len(peer.PSKIdentity) == 0
+	case doxm.ManufacturerCertificate:
+		return len(peer.Certificates) > 0
+	}
+	return false
+}
+
+// hasAccessDuringOwnershipTransferLocked allows the secure connection established by the ownership transfer method
+// to access only the security resources. The unsecure connection can only discover the device, read the ownership
+// and select the ownership transfer method.
+func (m *Manager) hasAccessDuringOwnershipTransferLocked(peer *net.PeerIdentity, href string, code codes.Code) bool {
+	if peer != nil {
+		return m.isOwnerTransferSessionLocked(peer) && IsSecurityResource(href)
+	}
+	switch code {
+	case codes.GET:
+		return href == resources.ResourceURI || href == device.ResourceURI || href == doxm.ResourceURI
+	case codes.POST:
+		return href == doxm.ResourceURI
+	}
+	return false
+}
+
+// HasAccess evaluates the request against the access control list. The resource owner has always access to the security resources.
+func (m *Manager) HasAccess(req *net.Request) bool {
+	return m.HasAccessToResource(req.Peer, req.URIPath(), req.Code())
+}
+
+// HasAccessToResource evaluates the request of the peer with the code to the resource href against the access control list,
+// it is used for the resources which are accessed indirectly, e.g. by the batch interface.
+func (m *Manager) HasAccessToResource(peer *net.PeerIdentity, href string, code codes.Code) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.cfg.OperationalState == pstat.OperationalState_RFOTM {
+		return m.hasAccessDuringOwnershipTransferLocked(peer, href, code)
+	}
+	authenticated := m.isAuthenticated(peer)
+	if IsSecurityResource(href) && m.isResourceOwnerLocked(peer, authenticated) {
+		return true
+	}
+	permission := toPermission(code)
+	for _, ace := range m.cfg.AccessControlList {
+		if !ace.Permission.Has(permission) {
+			continue
+		}
+		if matchSubject(ace.Subject, peer, authenticated) && matchResource(ace.Resources, href) {
+			return true
+		}
+	}
+	return false
+}
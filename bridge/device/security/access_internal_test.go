@@ -0,0 +1,220 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/net"
+	"github.com/plgd-dev/device/v2/pkg/security/generateCertificate"
+	pkgX509 "github.com/plgd-dev/device/v2/pkg/security/x509"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/stretchr/testify/require"
+)
+
+type testCredentials struct {
+	cas  []*x509.Certificate
+	psks map[string][]byte
+}
+
+func (c *testCredentials) GetCAPool() []*x509.Certificate {
+	return c.cas
+}
+
+func (c *testCredentials) GetPreSharedKey(subject string) ([]byte, bool) {
+	psk, ok := c.psks[subject]
+	return psk, ok
+}
+
+func generateRootCA(t *testing.T) ([]*x509.Certificate, *ecdsa.PrivateKey) {
+	cfg := generateCertificate.Configuration{ValidFor: time.Hour}
+	privateKey, err := cfg.GenerateKey()
+	require.NoError(t, err)
+	pemCert, err := generateCertificate.GenerateRootCA(cfg, privateKey)
+	require.NoError(t, err)
+	certs, err := pkgX509.ParsePemCertificates(pemCert)
+	require.NoError(t, err)
+	return certs, privateKey
+}
+
+func generateIdentityCertificate(t *testing.T, id uuid.UUID, ca []*x509.Certificate, caKey *ecdsa.PrivateKey) []*x509.Certificate {
+	cfg := generateCertificate.Configuration{ValidFor: time.Hour}
+	privateKey, err := cfg.GenerateKey()
+	require.NoError(t, err)
+	pemCert, err := generateCertificate.GenerateIdentityCert(cfg, id.String(), privateKey, ca, caKey)
+	require.NoError(t, err)
+	certs, err := pkgX509.ParsePemCertificates(pemCert)
+	require.NoError(t, err)
+	return certs
+}
+
+func TestMatchSubject(t *testing.T) {
+	peerID := uuid.New()
+	peer := &net.PeerIdentity{PSKIdentity: peerID[:]}
+	tests := []struct {
+		name          string
+		subject       acl.Subject
+		peer          *net.PeerIdentity
+		authenticated bool
+		want          bool
+	}{
+		{
+			name:    "anon-clear",
+			subject: acl.Subject{Subject_Connection: &acl.Subject_Connection{Type: acl.ConnectionType_ANON_CLEAR}},
+			want:    true,
+		},
+		{
+			name:    "auth-crypt-unsecure",
+			subject: acl.TLSConnection,
+		},
+		{
+			name:    "auth-crypt-anonymous",
+			subject: acl.TLSConnection,
+			peer:    &net.PeerIdentity{},
+		},
+		{
+			name:          "auth-crypt",
+			subject:       acl.TLSConnection,
+			peer:          peer,
+			authenticated: true,
+			want:          true,
+		},
+		{
+			name:          "device",
+			subject:       acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: peerID.String()}},
+			peer:          peer,
+			authenticated: true,
+			want:          true,
+		},
+		{
+			name:    "device-unauthenticated",
+			subject: acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: peerID.String()}},
+			peer:    peer,
+		},
+		{
+			name:          "device-wildcard",
+			subject:       acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: "*"}},
+			peer:          peer,
+			authenticated: true,
+			want:          true,
+		},
+		{
+			name:          "other-device",
+			subject:       acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: uuid.NewString()}},
+			peer:          peer,
+			authenticated: true,
+		},
+		{
+			name:    "device-unsecure",
+			subject: acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: peerID.String()}},
+		},
+		{
+			name:          "role-without-certificate",
+			subject:       acl.Subject{Subject_Role: &acl.Subject_Role{Role: "admin"}},
+			peer:          peer,
+			authenticated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, matchSubject(tt.subject, tt.peer, tt.authenticated))
+		})
+	}
+}
+
+func TestIsAuthenticated(t *testing.T) {
+	deviceID := uuid.New()
+	peerID := uuid.New()
+	ca, caKey := generateRootCA(t)
+	otherCA, otherCAKey := generateRootCA(t)
+	credentials := &testCredentials{
+		cas:  ca,
+		psks: map[string][]byte{peerID.String(): []byte("0123456789abcdef")},
+	}
+	m, err := New(Config{}, deviceID, nil, WithCredentials(credentials))
+	require.NoError(t, err)
+	withoutCredentials, err := New(Config{}, deviceID, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		m    *Manager
+		peer *net.PeerIdentity
+		want bool
+	}{
+		{
+			name: "unsecure",
+			m:    m,
+		},
+		{
+			name: "anonymous",
+			m:    m,
+			peer: &net.PeerIdentity{},
+		},
+		{
+			name: "psk",
+			m:    m,
+			peer: &net.PeerIdentity{PSKIdentity: peerID[:]},
+			want: true,
+		},
+		{
+			name: "psk-not-provisioned",
+			m:    m,
+			peer: &net.PeerIdentity{PSKIdentity: []byte(uuid.NewString())},
+		},
+		{
+			name: "psk-without-credentials",
+			m:    withoutCredentials,
+			peer: &net.PeerIdentity{PSKIdentity: peerID[:]},
+		},
+		{
+			name: "certificate",
+			m:    m,
+			peer: &net.PeerIdentity{Certificates: generateIdentityCertificate(t, peerID, ca, caKey)},
+			want: true,
+		},
+		{
+			name: "certificate-not-provisioned-ca",
+			m:    m,
+			peer: &net.PeerIdentity{Certificates: generateIdentityCertificate(t, peerID, otherCA, otherCAKey)},
+		},
+		{
+			name: "certificate-without-credentials",
+			m:    withoutCredentials,
+			peer: &net.PeerIdentity{Certificates: generateIdentityCertificate(t, peerID, ca, caKey)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.m.isAuthenticated(tt.peer))
+		})
+	}
+}
+
+func TestMatchResource(t *testing.T) {
+	require.True(t, matchResource(acl.AllResources, "/light/1"))
+	require.False(t, matchResource(acl.AllResources, credential.ResourceURI))
+	require.True(t, matchResource([]acl.Resource{{Href: credential.ResourceURI}}, credential.ResourceURI))
+	require.False(t, matchResource([]acl.Resource{{Href: "/light/2"}}, "/light/1"))
+}
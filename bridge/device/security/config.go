@@ -0,0 +1,79 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package security
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+)
+
+// Config contains the state of the security resources of the device.
+type Config struct {
+	// OwnerTransferMethods supported by the device, only JustWorks and ManufacturerCertificate are supported.
+	OwnerTransferMethods        []doxm.OwnerTransferMethod `yaml:"ownerTransferMethods" json:"oxms"`
+	SelectedOwnerTransferMethod doxm.OwnerTransferMethod   `yaml:"-" json:"oxmsel"`
+	Owned                       bool                       `yaml:"-" json:"owned"`
+	OwnerID                     string                     `yaml:"-" json:"devowneruuid"`
+	ResourceOwner               string                     `yaml:"-" json:"rowneruuid"`
+	OperationalState            pstat.OperationalState     `yaml:"-" json:"s"`
+	OperationalMode             pstat.OperationalMode      `yaml:"-" json:"om"`
+	AccessControlList           []acl.AccessControl        `yaml:"-" json:"aclist2"`
+	// PrivateKey of the device in PEM format, it is generated for the certificate signing request.
+	PrivateKey []byte `yaml:"-" json:"privateKey,omitempty"`
+}
+
+func (cfg *Config) Validate() error {
+	if len(cfg.OwnerTransferMethods) == 0 {
+		cfg.OwnerTransferMethods = []doxm.OwnerTransferMethod{doxm.JustWorks}
+	}
+	for _, oxm := range cfg.OwnerTransferMethods {
+		if oxm != doxm.JustWorks && oxm != doxm.ManufacturerCertificate {
+			return fmt.Errorf("ownerTransferMethods('%v') - %v is not supported", cfg.OwnerTransferMethods, oxm)
+		}
+	}
+	if !cfg.supportsOwnerTransferMethod(cfg.SelectedOwnerTransferMethod) {
+		cfg.SelectedOwnerTransferMethod = cfg.OwnerTransferMethods[0]
+	}
+	if cfg.OwnerID == "" {
+		cfg.OwnerID = uuid.Nil.String()
+	}
+	if cfg.ResourceOwner == "" {
+		cfg.ResourceOwner = uuid.Nil.String()
+	}
+	if cfg.OperationalState == pstat.OperationalState_RESET {
+		cfg.OperationalState = pstat.OperationalState_RFOTM
+	}
+	if cfg.OperationalMode == 0 {
+		cfg.OperationalMode = pstat.OperationalMode_CLIENT_DIRECTED
+	}
+	return nil
+}
+
+func (cfg *Config) supportsOwnerTransferMethod(oxm doxm.OwnerTransferMethod) bool {
+	for _, v := range cfg.OwnerTransferMethods {
+		if v == oxm {
+			return true
+		}
+	}
+	return false
+}
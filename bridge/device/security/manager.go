@@ -0,0 +1,449 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/net"
+	"github.com/plgd-dev/device/v2/bridge/resources"
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	"github.com/plgd-dev/device/v2/pkg/log"
+	"github.com/plgd-dev/device/v2/pkg/security/generateCertificate"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/csr"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+)
+
+// Manager implements the ownership transfer (/oic/sec/doxm), the provisioning status (/oic/sec/pstat),
+// the access control list (/oic/sec/acl2) and the certificate signing request (/oic/sec/csr) of the device.
+type Manager struct {
+	deviceID    uuid.UUID
+	save        func()
+	onReset     OnReset
+	logger      log.Logger
+	credentials Credentials
+
+	mutex sync.Mutex
+	cfg   Config
+}
+
+func New(cfg Config, deviceID uuid.UUID, save func(), opts ...Option) (*Manager, error) {
+	o := OptionsCfg{
+		onReset: func() {
+			// do nothing
+		},
+		logger: log.NewNilLogger(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if save == nil {
+		save = func() {
+			// do nothing
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid security configuration: %w", err)
+	}
+	return &Manager{
+		deviceID:    deviceID,
+		save:        save,
+		onReset:     o.onReset,
+		logger:      o.logger,
+		credentials: o.credentials,
+		cfg:         cfg,
+	}, nil
+}
+
+func (m *Manager) ExportConfig() Config {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	cfg := m.cfg
+	cfg.OwnerTransferMethods = append([]doxm.OwnerTransferMethod(nil), m.cfg.OwnerTransferMethods...)
+	cfg.AccessControlList = append([]acl.AccessControl(nil), m.cfg.AccessControlList...)
+	return cfg
+}
+
+// GetOperationalState returns the current operational state of the device.
+func (m *Manager) GetOperationalState() pstat.OperationalState {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.cfg.OperationalState
+}
+
+// IsOwned returns true if the ownership transfer was finished.
+func (m *Manager) IsOwned() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.cfg.Owned
+}
+
+func (m *Manager) getDoxmLocked() doxm.Doxm {
+	return doxm.Doxm{
+		ResourceOwner:                 m.cfg.ResourceOwner,
+		SupportedOwnerTransferMethods: m.cfg.OwnerTransferMethods,
+		OwnerID:                       m.cfg.OwnerID,
+		DeviceID:                      m.deviceID.String(),
+		Owned:                         m.cfg.Owned,
+		SupportedCredentialTypes:      credential.CredentialType_SYMMETRIC_PAIR_WISE | credential.CredentialType_ASYMMETRIC_SIGNING_WITH_CERTIFICATE,
+		SelectedOwnerTransferMethod:   m.cfg.SelectedOwnerTransferMethod,
+		Interfaces:                    []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_RW},
+		ResourceTypes:                 []string{doxm.ResourceType},
+	}
+}
+
+func (m *Manager) GetDoxm(request *net.Request) (*pool.Message, error) {
+	m.mutex.Lock()
+	rep := m.getDoxmLocked()
+	m.mutex.Unlock()
+	return resources.CreateResponseContent(request.Context(), rep, codes.Content)
+}
+
+func parseUUID(name, v string) (string, error) {
+	id, err := uuid.Parse(v)
+	if err != nil {
+		return "", fmt.Errorf("invalid %v('%v'): %w", name, v, err)
+	}
+	return id.String(), nil
+}
+
+func (m *Manager) updateDoxmLocked(upd doxm.DoxmUpdate) error {
+	if m.cfg.OperationalState != pstat.OperationalState_RFOTM {
+		return fmt.Errorf("doxm cannot be updated in the %v state", m.cfg.OperationalState)
+	}
+	cfg := m.cfg
+	if upd.SelectOwnerTransferMethod != nil {
+		if !cfg.supportsOwnerTransferMethod(*upd.SelectOwnerTransferMethod) {
+			return fmt.Errorf("owner transfer method %v is not supported", *upd.SelectOwnerTransferMethod)
+		}
+		cfg.SelectedOwnerTransferMethod = *upd.SelectOwnerTransferMethod
+	}
+	if upd.DeviceID != nil {
+		deviceID, err := parseUUID("deviceuuid", *upd.DeviceID)
+		if err != nil {
+			return err
+		}
+		if deviceID != m.deviceID.String() {
+			return errors.New("change of the deviceuuid is not supported")
+		}
+	}
+	if upd.OwnerID != nil {
+		ownerID, err := parseUUID("devowneruuid", *upd.OwnerID)
+		if err != nil {
+			return err
+		}
+		cfg.OwnerID = ownerID
+	}
+	if upd.ResourceOwner != nil {
+		resourceOwner, err := parseUUID("rowneruuid", *upd.ResourceOwner)
+		if err != nil {
+			return err
+		}
+		cfg.ResourceOwner = resourceOwner
+	}
+	if upd.Owned != nil {
+		if *upd.Owned && cfg.OwnerID == uuid.Nil.String() {
+			return errors.New("devowneruuid must be set before the device is owned")
+		}
+		cfg.Owned = *upd.Owned
+	}
+	m.cfg = cfg
+	return nil
+}
+
+func (m *Manager) PostDoxm(request *net.Request) (*pool.Message, error) {
+	var upd doxm.DoxmUpdate
+	err := cbor.ReadFrom(request.Body(), &upd)
+	if err != nil {
+		return resources.CreateResponseBadRequest(request.Context(), err)
+	}
+	m.mutex.Lock()
+	err = m.updateDoxmLocked(upd)
+	rep := m.getDoxmLocked()
+	m.mutex.Unlock()
+	if err != nil {
+		return resources.CreateResponseBadRequest(request.Context(), err)
+	}
+	m.save()
+	return resources.CreateResponseContent(request.Context(), rep, codes.Changed)
+}
+
+func (m *Manager) getPstatLocked() pstat.ProvisionStatusResponse {
+	return pstat.ProvisionStatusResponse{
+		ResourceOwner:             m.cfg.ResourceOwner,
+		Interfaces:                []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_RW},
+		ResourceTypes:             []string{pstat.ResourceType},
+		CurrentOperationalMode:    m.cfg.OperationalMode,
+		DeviceIsOperational:       m.cfg.OperationalState == pstat.OperationalState_RFNOP,
+		SupportedOperationalModes: pstat.OperationalMode_CLIENT_DIRECTED,
+		DeviceOnboardingState: pstat.DeviceOnboardingState{
+			CurrentOrPendingOperationalState: m.cfg.OperationalState,
+		},
+	}
+}
+
+func (m *Manager) GetPstat(request *net.Request) (*pool.Message, error) {
+	m.mutex.Lock()
+	rep := m.getPstatLocked()
+	m.mutex.Unlock()
+	return resources.CreateResponseContent(request.Context(), rep, codes.Content)
+}
+
+func isValidTransition(from, to pstat.OperationalState, owned bool) bool {
+	switch to {
+	case pstat.OperationalState_RFPRO:
+		return (from == pstat.OperationalState_RFOTM && owned) || from == pstat.OperationalState_RFNOP || from == pstat.OperationalState_SRESET
+	case pstat.OperationalState_RFNOP:
+		return from == pstat.OperationalState_RFPRO
+	case pstat.OperationalState_SRESET:
+		return from == pstat.OperationalState_RFPRO || from == pstat.OperationalState_RFNOP
+	}
+	return false
+}
+
+func (m *Manager) resetLocked() {
+	m.cfg = Config{
+		OwnerTransferMethods: m.cfg.OwnerTransferMethods,
+	}
+	// the configuration was already validated, so only the defaults are set
+	_ = m.cfg.Validate()
+}
+
+// updatePstatLocked returns true when the device must be reset.
+func (m *Manager) updatePstatLocked(upd pstat.ProvisionStatusUpdateRequest) (bool, error) {
+	cfg := m.cfg
+	if upd.CurrentOperationalMode != 0 {
+		if upd.CurrentOperationalMode != pstat.OperationalMode_CLIENT_DIRECTED {
+			return false, fmt.Errorf("operational mode %v is not supported", upd.CurrentOperationalMode)
+		}
+		cfg.OperationalMode = upd.CurrentOperationalMode
+	}
+	if upd.ResourceOwner != "" {
+		resourceOwner, err := parseUUID("rowneruuid", upd.ResourceOwner)
+		if err != nil {
+			return false, err
+		}
+		cfg.ResourceOwner = resourceOwner
+	}
+	if upd.DeviceOnboardingState != nil {
+		to := upd.DeviceOnboardingState.CurrentOrPendingOperationalState
+		if to == pstat.OperationalState_RESET {
+			return true, nil
+		}
+		if to != cfg.OperationalState {
+			if !isValidTransition(cfg.OperationalState, to, cfg.Owned) {
+				return false, fmt.Errorf("invalid transition from %v to %v", cfg.OperationalState, to)
+			}
+			m.logger.Infof("operational state changed from %v to %v", cfg.OperationalState, to)
+			cfg.OperationalState = to
+		}
+	}
+	m.cfg = cfg
+	return false, nil
+}
+
+func (m *Manager) PostPstat(request *net.Request) (*pool.Message, error) {
+	var upd pstat.ProvisionStatusUpdateRequest
+	err := cbor.ReadFrom(request.Body(), &upd)
+	if err != nil {
+		return resources.CreateResponseBadRequest(request.Context(), err)
+	}
+	m.mutex.Lock()
+	reset, err := m.updatePstatLocked(upd)
+	m.mutex.Unlock()
+	if err != nil {
+		return resources.CreateResponseBadRequest(request.Context(), err)
+	}
+	if reset {
+		m.Reset()
+	} else {
+		m.save()
+	}
+	m.mutex.Lock()
+	rep := m.getPstatLocked()
+	m.mutex.Unlock()
+	return resources.CreateResponseContent(request.Context(), rep, codes.Changed)
+}
+
+// Reset moves the device to the ready for ownership transfer state. The owner, the access control list
+// and the private key are removed and the OnReset handler is called.
+func (m *Manager) Reset() {
+	m.mutex.Lock()
+	m.resetLocked()
+	m.mutex.Unlock()
+	m.logger.Infof("operational state reset to %v", pstat.OperationalState_RFOTM)
+	m.onReset()
+	m.save()
+}
+
+func (m *Manager) getACLLocked() acl.Response {
+	return acl.Response{
+		ResourceOwner:     m.cfg.ResourceOwner,
+		Interfaces:        []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_RW},
+		ResourceTypes:     []string{acl.ResourceType},
+		AccessControlList: append([]acl.AccessControl{}, m.cfg.AccessControlList...),
+	}
+}
+
+func (m *Manager) GetACL(request *net.Request) (*pool.Message, error) {
+	m.mutex.Lock()
+	rep := m.getACLLocked()
+	m.mutex.Unlock()
+	return resources.CreateResponseContent(request.Context(), rep, codes.Content)
+}
+
+func (m *Manager) getNextACEIDLocked() int {
+	var id int
+	for _, ace := range m.cfg.AccessControlList {
+		if ace.ID > id {
+			id = ace.ID
+		}
+	}
+	return id + 1
+}
+
+func (m *Manager) addOrReplaceACELocked(ace acl.AccessControl) {
+	if ace.ID != 0 {
+		for i := range m.cfg.AccessControlList {
+			if m.cfg.AccessControlList[i].ID == ace.ID {
+				m.cfg.AccessControlList[i] = ace
+				return
+			}
+		}
+	} else {
+		ace.ID = m.getNextACEIDLocked()
+	}
+	m.cfg.AccessControlList = append(m.cfg.AccessControlList, ace)
+}
+
+func (m *Manager) PostACL(request *net.Request) (*pool.Message, error) {
+	var upd acl.UpdateRequest
+	err := cbor.ReadFrom(request.Body(), &upd)
+	if err != nil {
+		return resources.CreateResponseBadRequest(request.Context(), err)
+	}
+	resourceOwner := ""
+	if upd.ResourceOwner != "" {
+		resourceOwner, err = parseUUID("rowneruuid", upd.ResourceOwner)
+		if err != nil {
+			return resources.CreateResponseBadRequest(request.Context(), err)
+		}
+	}
+	m.mutex.Lock()
+	if resourceOwner != "" {
+		m.cfg.ResourceOwner = resourceOwner
+	}
+	for _, ace := range upd.AccessControlList {
+		m.addOrReplaceACELocked(ace)
+	}
+	rep := m.getACLLocked()
+	m.mutex.Unlock()
+	m.save()
+	return resources.CreateResponseContent(request.Context(), rep, codes.Changed)
+}
+
+// DeleteACL removes the access control entry identified by the aceid query or all entries when the query is not set.
+func (m *Manager) DeleteACL(request *net.Request) (*pool.Message, error) {
+	var aceID int
+	if v, err := request.GetValueFromQuery("aceid"); err == nil {
+		aceID, err = strconv.Atoi(v)
+		if err != nil {
+			return resources.CreateResponseBadRequest(request.Context(), fmt.Errorf("invalid aceid('%v'): %w", v, err))
+		}
+	}
+	m.mutex.Lock()
+	aces := make([]acl.AccessControl, 0, len(m.cfg.AccessControlList))
+	if aceID != 0 {
+		for _, ace := range m.cfg.AccessControlList {
+			if ace.ID != aceID {
+				aces = append(aces, ace)
+			}
+		}
+	}
+	m.cfg.AccessControlList = aces
+	m.mutex.Unlock()
+	m.save()
+	return resources.CreateResponseContent(request.Context(), "", codes.Deleted)
+}
+
+func (m *Manager) getPrivateKeyLocked() (*ecdsa.PrivateKey, error) {
+	if len(m.cfg.PrivateKey) > 0 {
+		block, _ := pem.Decode(m.cfg.PrivateKey)
+		if block == nil {
+			return nil, errors.New("cannot decode private key")
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	m.cfg.PrivateKey = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	return key, nil
+}
+
+func (m *Manager) createCSR() ([]byte, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	generated := len(m.cfg.PrivateKey) == 0
+	key, err := m.getPrivateKeyLocked()
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot get private key: %w", err)
+	}
+	req, err := generateCertificate.GenerateIdentityCSR(generateCertificate.Configuration{}, m.deviceID.String(), key)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot create certificate signing request: %w", err)
+	}
+	return req, generated, nil
+}
+
+func (m *Manager) GetCSR(request *net.Request) (*pool.Message, error) {
+	req, generated, err := m.createCSR()
+	if err != nil {
+		return resources.CreateErrorResponse(request.Context(), codes.InternalServerError, err)
+	}
+	if generated {
+		m.save()
+	}
+	return resources.CreateResponseContent(request.Context(), csr.CertificateSigningRequestResponse{
+		Interfaces:                []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_R},
+		ResourceTypes:             []string{csr.ResourceType},
+		Encoding:                  csr.CertificateEncoding_PEM,
+		CertificateSigningRequest: string(req),
+	}, codes.Content)
+}
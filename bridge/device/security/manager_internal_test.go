@@ -0,0 +1,260 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/net"
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/csr"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/pstat"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/stretchr/testify/require"
+)
+
+func newRequest(t *testing.T, code codes.Code, uri string, body interface{}, peer *net.PeerIdentity, queries ...string) *net.Request {
+	msg := pool.NewMessage(context.Background())
+	msg.SetCode(code)
+	err := msg.SetPath(uri)
+	require.NoError(t, err)
+	msg.SetToken([]byte{0x01})
+	for _, q := range queries {
+		msg.AddQuery(q)
+	}
+	if body != nil {
+		data, err := cbor.Encode(body)
+		require.NoError(t, err)
+		msg.SetBody(bytes.NewReader(data))
+	}
+	return &net.Request{
+		Message: msg,
+		Peer:    peer,
+	}
+}
+
+func pskPeer(t *testing.T, id uuid.UUID) *net.PeerIdentity {
+	idBin, err := id.MarshalBinary()
+	require.NoError(t, err)
+	return &net.PeerIdentity{PSKIdentity: idBin}
+}
+
+func handle(t *testing.T, m *Manager, h func(*net.Request) (*pool.Message, error), req *net.Request) *pool.Message {
+	require.True(t, m.HasAccess(req), "access denied to %v", req.URIPath())
+	resp, err := h(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	deviceID := uuid.New()
+	ownerID := uuid.New()
+	var saved int
+	// the owner credential is provisioned by the ownership transfer
+	credentials := &testCredentials{psks: map[string][]byte{ownerID.String(): []byte("0123456789abcdef")}}
+	m, err := New(Config{}, deviceID, func() { saved++ }, WithCredentials(credentials))
+	require.NoError(t, err)
+	otmSession := &net.PeerIdentity{}
+
+	// select OTM over the unsecure connection
+	oxm := doxm.JustWorks
+	resp := handle(t, m, m.PostDoxm, newRequest(t, codes.POST, doxm.ResourceURI, doxm.DoxmUpdate{SelectOwnerTransferMethod: &oxm}, nil))
+	require.Equal(t, codes.Changed, resp.Code())
+	unsupported := doxm.SharedPin
+	resp = handle(t, m, m.PostDoxm, newRequest(t, codes.POST, doxm.ResourceURI, doxm.DoxmUpdate{SelectOwnerTransferMethod: &unsupported}, nil))
+	require.Equal(t, codes.BadRequest, resp.Code())
+	require.False(t, m.HasAccess(newRequest(t, codes.POST, pstat.ResourceURI, pstat.ProvisionStatusUpdateRequest{}, nil)))
+	require.True(t, m.HasAccess(newRequest(t, codes.GET, doxm.ResourceURI, nil, nil)))
+	require.True(t, m.HasAccess(newRequest(t, codes.GET, device.ResourceURI, nil, nil)))
+	require.False(t, m.HasAccess(newRequest(t, codes.GET, acl.ResourceURI, nil, nil)))
+	require.False(t, m.HasAccess(newRequest(t, codes.GET, "/light/1", nil, nil)))
+
+	// the OTM session can access only the security resources
+	require.False(t, m.HasAccess(newRequest(t, codes.GET, "/light/1", nil, otmSession)))
+	require.False(t, m.HasAccess(newRequest(t, codes.GET, device.ResourceURI, nil, otmSession)))
+	require.False(t, m.HasAccess(newRequest(t, codes.GET, acl.ResourceURI, nil, pskPeer(t, ownerID))))
+
+	// owner transfer over the OTM session
+	resp = handle(t, m, m.PostPstat, newRequest(t, codes.POST, pstat.ResourceURI, pstat.ProvisionStatusUpdateRequest{CurrentOperationalMode: pstat.OperationalMode_CLIENT_DIRECTED}, otmSession))
+	require.Equal(t, codes.Changed, resp.Code())
+	otherID := uuid.NewString()
+	resp = handle(t, m, m.PostDoxm, newRequest(t, codes.POST, doxm.ResourceURI, doxm.DoxmUpdate{DeviceID: &otherID}, otmSession))
+	require.Equal(t, codes.BadRequest, resp.Code())
+	owned := true
+	resp = handle(t, m, m.PostDoxm, newRequest(t, codes.POST, doxm.ResourceURI, doxm.DoxmUpdate{Owned: &owned}, otmSession))
+	require.Equal(t, codes.BadRequest, resp.Code())
+	resp = handle(t, m, m.PostPstat, newRequest(t, codes.POST, pstat.ResourceURI, pstat.ProvisionStatusUpdateRequest{DeviceOnboardingState: &pstat.DeviceOnboardingState{CurrentOrPendingOperationalState: pstat.OperationalState_RFPRO}}, otmSession))
+	require.Equal(t, codes.BadRequest, resp.Code())
+	owner := ownerID.String()
+	resp = handle(t, m, m.PostDoxm, newRequest(t, codes.POST, doxm.ResourceURI, doxm.DoxmUpdate{OwnerID: &owner}, otmSession))
+	require.Equal(t, codes.Changed, resp.Code())
+	resp = handle(t, m, m.PostPstat, newRequest(t, codes.POST, pstat.ResourceURI, pstat.ProvisionStatusUpdateRequest{ResourceOwner: owner}, otmSession))
+	require.Equal(t, codes.Changed, resp.Code())
+	resp = handle(t, m, m.PostACL, newRequest(t, codes.POST, acl.ResourceURI, acl.UpdateRequest{ResourceOwner: owner}, otmSession))
+	require.Equal(t, codes.Changed, resp.Code())
+	resp = handle(t, m, m.PostDoxm, newRequest(t, codes.POST, doxm.ResourceURI, doxm.DoxmUpdate{ResourceOwner: &owner, Owned: &owned}, otmSession))
+	require.Equal(t, codes.Changed, resp.Code())
+	var ownership doxm.Doxm
+	err = cbor.ReadFrom(resp.Body(), &ownership)
+	require.NoError(t, err)
+	require.True(t, ownership.Owned)
+	require.Equal(t, owner, ownership.OwnerID)
+	require.Equal(t, deviceID.String(), ownership.DeviceID)
+	resp = handle(t, m, m.PostPstat, newRequest(t, codes.POST, pstat.ResourceURI, pstat.ProvisionStatusUpdateRequest{DeviceOnboardingState: &pstat.DeviceOnboardingState{CurrentOrPendingOperationalState: pstat.OperationalState_RFPRO}}, otmSession))
+	require.Equal(t, codes.Changed, resp.Code())
+
+	// the OTM session has no access in RFPRO, only the owner
+	require.False(t, m.HasAccess(newRequest(t, codes.DELETE, acl.ResourceURI, nil, otmSession)))
+	ownerSession := pskPeer(t, ownerID)
+	// the pre-shared key is provisioned only for the owner
+	require.False(t, m.HasAccess(newRequest(t, codes.DELETE, acl.ResourceURI, nil, pskPeer(t, uuid.New()))))
+	resp = handle(t, m, m.DeleteACL, newRequest(t, codes.DELETE, acl.ResourceURI, nil, ownerSession))
+	require.Equal(t, codes.Deleted, resp.Code())
+	resp = handle(t, m, m.PostACL, newRequest(t, codes.POST, acl.ResourceURI, acl.UpdateRequest{
+		AccessControlList: []acl.AccessControl{
+			{
+				Permission: acl.AllPermissions,
+				Subject:    acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: owner}},
+				Resources:  acl.AllResources,
+			},
+			{
+				Permission: acl.Permission_READ,
+				Subject:    acl.Subject{Subject_Connection: &acl.Subject_Connection{Type: acl.ConnectionType_ANON_CLEAR}},
+				Resources:  []acl.Resource{{Href: device.ResourceURI, Interfaces: []string{"*"}}},
+			},
+		},
+	}, ownerSession))
+	require.Equal(t, codes.Changed, resp.Code())
+	resp = handle(t, m, m.PostPstat, newRequest(t, codes.POST, pstat.ResourceURI, pstat.ProvisionStatusUpdateRequest{DeviceOnboardingState: &pstat.DeviceOnboardingState{CurrentOrPendingOperationalState: pstat.OperationalState_RFNOP}}, ownerSession))
+	require.Equal(t, codes.Changed, resp.Code())
+	var provisionState pstat.ProvisionStatusResponse
+	err = cbor.ReadFrom(resp.Body(), &provisionState)
+	require.NoError(t, err)
+	require.True(t, provisionState.DeviceIsOperational)
+	require.Equal(t, pstat.OperationalState_RFNOP, m.GetOperationalState())
+
+	// doxm can be updated only in RFOTM
+	resp = handle(t, m, m.PostDoxm, newRequest(t, codes.POST, doxm.ResourceURI, doxm.DoxmUpdate{OwnerID: &otherID}, ownerSession))
+	require.Equal(t, codes.BadRequest, resp.Code())
+
+	// the state is restored from the exported configuration
+	require.Positive(t, saved)
+	m, err = New(m.ExportConfig(), deviceID, nil, WithCredentials(credentials))
+	require.NoError(t, err)
+	require.True(t, m.IsOwned())
+	require.Equal(t, pstat.OperationalState_RFNOP, m.GetOperationalState())
+	require.True(t, m.HasAccess(newRequest(t, codes.POST, "/light/1", nil, ownerSession)))
+	require.True(t, m.HasAccess(newRequest(t, codes.GET, device.ResourceURI, nil, nil)))
+	require.False(t, m.HasAccess(newRequest(t, codes.GET, "/light/1", nil, nil)))
+	require.False(t, m.HasAccess(newRequest(t, codes.GET, "/light/1", nil, pskPeer(t, uuid.New()))))
+}
+
+func TestReset(t *testing.T) {
+	var reset bool
+	m, err := New(Config{
+		Owned:             true,
+		OwnerID:           uuid.NewString(),
+		ResourceOwner:     uuid.NewString(),
+		OperationalState:  pstat.OperationalState_RFNOP,
+		AccessControlList: []acl.AccessControl{{ID: 1, Permission: acl.AllPermissions, Subject: acl.TLSConnection, Resources: acl.AllResources}},
+	}, uuid.New(), nil, WithOnReset(func() { reset = true }))
+	require.NoError(t, err)
+
+	resp, err := m.PostPstat(newRequest(t, codes.POST, pstat.ResourceURI, pstat.ProvisionStatusUpdateRequest{DeviceOnboardingState: &pstat.DeviceOnboardingState{CurrentOrPendingOperationalState: pstat.OperationalState_RESET}}, nil))
+	require.NoError(t, err)
+	require.Equal(t, codes.Changed, resp.Code())
+	require.True(t, reset)
+	cfg := m.ExportConfig()
+	require.False(t, cfg.Owned)
+	require.Equal(t, uuid.Nil.String(), cfg.OwnerID)
+	require.Equal(t, pstat.OperationalState_RFOTM, cfg.OperationalState)
+	require.Empty(t, cfg.AccessControlList)
+}
+
+func TestDeleteACL(t *testing.T) {
+	m, err := New(Config{
+		AccessControlList: []acl.AccessControl{
+			{ID: 1, Permission: acl.Permission_READ, Subject: acl.TLSConnection, Resources: acl.AllResources},
+			{ID: 2, Permission: acl.Permission_WRITE, Subject: acl.TLSConnection, Resources: acl.AllResources},
+		},
+	}, uuid.New(), nil)
+	require.NoError(t, err)
+
+	resp, err := m.DeleteACL(newRequest(t, codes.DELETE, acl.ResourceURI, nil, nil, "aceid=invalid"))
+	require.NoError(t, err)
+	require.Equal(t, codes.BadRequest, resp.Code())
+
+	resp, err = m.DeleteACL(newRequest(t, codes.DELETE, acl.ResourceURI, nil, nil, "aceid=1"))
+	require.NoError(t, err)
+	require.Equal(t, codes.Deleted, resp.Code())
+	aces := m.ExportConfig().AccessControlList
+	require.Len(t, aces, 1)
+	require.Equal(t, 2, aces[0].ID)
+
+	resp, err = m.PostACL(newRequest(t, codes.POST, acl.ResourceURI, acl.UpdateRequest{
+		AccessControlList: []acl.AccessControl{{Permission: acl.Permission_READ, Subject: acl.TLSConnection, Resources: acl.AllResources}},
+	}, nil))
+	require.NoError(t, err)
+	require.Equal(t, codes.Changed, resp.Code())
+	aces = m.ExportConfig().AccessControlList
+	require.Len(t, aces, 2)
+	require.Equal(t, 3, aces[1].ID)
+
+	resp, err = m.DeleteACL(newRequest(t, codes.DELETE, acl.ResourceURI, nil, nil))
+	require.NoError(t, err)
+	require.Equal(t, codes.Deleted, resp.Code())
+	require.Empty(t, m.ExportConfig().AccessControlList)
+}
+
+func TestGetCSR(t *testing.T) {
+	deviceID := uuid.New()
+	var saved int
+	m, err := New(Config{}, deviceID, func() { saved++ })
+	require.NoError(t, err)
+
+	getCSR := func() *x509.CertificateRequest {
+		resp, errG := m.GetCSR(newRequest(t, codes.GET, csr.ResourceURI, nil, nil))
+		require.NoError(t, errG)
+		require.Equal(t, codes.Content, resp.Code())
+		var rep csr.CertificateSigningRequestResponse
+		errG = cbor.ReadFrom(resp.Body(), &rep)
+		require.NoError(t, errG)
+		require.Equal(t, csr.CertificateEncoding_PEM, rep.Encoding)
+		block, _ := pem.Decode(rep.CSR())
+		require.NotNil(t, block)
+		req, errG := x509.ParseCertificateRequest(block.Bytes)
+		require.NoError(t, errG)
+		require.Equal(t, "uuid:"+deviceID.String(), req.Subject.CommonName)
+		return req
+	}
+	first := getCSR()
+	second := getCSR()
+	// the private key is generated only once
+	require.Equal(t, 1, saved)
+	require.Equal(t, first.PublicKey, second.PublicKey)
+}
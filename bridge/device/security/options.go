@@ -0,0 +1,64 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package security
+
+import (
+	"crypto/x509"
+
+	"github.com/plgd-dev/device/v2/pkg/log"
+)
+
+// OnReset is called when the device is reset to the ready for ownership transfer state.
+type OnReset func()
+
+// Credentials provisioned to the device authenticate the peers of the secure connections.
+type Credentials interface {
+	// GetCAPool returns the trusted certificate authorities, which verify the certificates of the peers.
+	GetCAPool() []*x509.Certificate
+	// GetPreSharedKey returns the pre-shared key of the subject.
+	GetPreSharedKey(subject string) ([]byte, bool)
+}
+
+type OptionsCfg struct {
+	onReset     OnReset
+	logger      log.Logger
+	credentials Credentials
+}
+
+type Option func(*OptionsCfg)
+
+// WithOnReset sets the handler, which is used to remove the credentials and the cloud configuration of the device after the reset.
+func WithOnReset(onReset OnReset) Option {
+	return func(o *OptionsCfg) {
+		o.onReset = onReset
+	}
+}
+
+func WithLogger(logger log.Logger) Option {
+	return func(o *OptionsCfg) {
+		o.logger = logger
+	}
+}
+
+// WithCredentials sets the credentials of the device, without them only the anonymous connections are matched by the access control list.
+func WithCredentials(credentials Credentials) Option {
+	return func(o *OptionsCfg) {
+		o.credentials = credentials
+	}
+}
@@ -35,12 +35,15 @@ func SupportedOperationToTDOperations(ops resources.SupportedOperation) []string
 		{resources.SupportedOperationRead, []string{string(thingDescription.Readproperty)}},
 		{resources.SupportedOperationWrite, []string{string(thingDescription.Writeproperty)}},
 		{resources.SupportedOperationObserve, []string{string(thingDescription.Observeproperty), string(thingDescription.Unobserveproperty)}},
+		// creating and deleting of the resource are write operations in the thing description
+		{resources.SupportedOperationCreate | resources.SupportedOperationDelete, []string{string(thingDescription.Writeproperty)}},
 	}
 	for _, t := range translationTable {
 		if ops.HasOperation(t.resourceOp) {
 			tdOps = append(tdOps, t.tdOps...)
 		}
 	}
+	tdOps = resources.Unique(tdOps)
 	if len(tdOps) == 0 {
 		return nil
 	}
@@ -68,10 +71,11 @@ type PropertyElementOperations struct {
 }
 
 func toPropertyElementOperations(ops resources.SupportedOperation) PropertyElementOperations {
+	writable := ops.HasOperation(resources.SupportedOperationWrite | resources.SupportedOperationCreate | resources.SupportedOperationDelete)
 	return PropertyElementOperations{
 		Observable: ops.HasOperation(resources.SupportedOperationObserve),
-		ReadOnly:   ops.HasOperation(resources.SupportedOperationRead) && !ops.HasOperation(resources.SupportedOperationWrite),
-		WriteOnly:  ops.HasOperation(resources.SupportedOperationWrite) && !ops.HasOperation(resources.SupportedOperationRead),
+		ReadOnly:   ops.HasOperation(resources.SupportedOperationRead) && !writable,
+		WriteOnly:  writable && !ops.HasOperation(resources.SupportedOperationRead),
 	}
 }
 
@@ -112,6 +116,10 @@ func CreateCOAPForm(hrefUri *url.URL, op thingDescription.StickyDescription, con
 	if !ok {
 		return thingDescription.FormElementProperty{}, false
 	}
+	return createCOAPForm(hrefUri, op, method, contentType), true
+}
+
+func createCOAPForm(hrefUri *url.URL, op thingDescription.StickyDescription, method string, contentType message.MediaType) thingDescription.FormElementProperty {
 	additionalFields := map[string]interface{}{
 		"cov:method": method,
 		"cov:accept": float64(contentType),
@@ -129,13 +137,13 @@ func CreateCOAPForm(hrefUri *url.URL, op thingDescription.StickyDescription, con
 			StringArray: ops,
 		},
 		AdditionalFields: additionalFields,
-	}, true
+	}
 }
 
 type CreateFormsFunc func(hrefUri *url.URL, ops resources.SupportedOperation, contentType message.MediaType) []thingDescription.FormElementProperty
 
 func CreateCOAPForms(hrefUri *url.URL, ops resources.SupportedOperation, contentType message.MediaType) []thingDescription.FormElementProperty {
-	forms := make([]thingDescription.FormElementProperty, 0, 3)
+	forms := make([]thingDescription.FormElementProperty, 0, 5)
 	if ops.HasOperation(resources.SupportedOperationWrite) {
 		form, ok := CreateCOAPForm(hrefUri, thingDescription.Writeproperty, contentType)
 		if ok {
@@ -154,6 +162,12 @@ func CreateCOAPForms(hrefUri *url.URL, ops resources.SupportedOperation, content
 			forms = append(forms, form)
 		}
 	}
+	if ops.HasOperation(resources.SupportedOperationCreate) {
+		forms = append(forms, createCOAPForm(hrefUri, thingDescription.Writeproperty, http.MethodPut, contentType))
+	}
+	if ops.HasOperation(resources.SupportedOperationDelete) {
+		forms = append(forms, createCOAPForm(hrefUri, thingDescription.Writeproperty, http.MethodDelete, contentType))
+	}
 	return forms
 }
 
@@ -19,18 +19,25 @@
 package net
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	gonet "net"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
+	"github.com/pion/dtls/v3"
+	"github.com/plgd-dev/device/v2/client/core/otm/just-works/cipher"
 )
 
 const (
 	UDP4 = "udp4"
 	UDP6 = "udp6"
+	TCP4 = "tcp4"
+	TCP6 = "tcp6"
 )
 
 var ErrInvalidExternalAddress = errors.New("invalid externalAddress")
@@ -63,10 +70,147 @@ func (extAddresses externalAddressesPort) filterByPort(port string) externalAddr
 	return filtered
 }
 
+// DTLSConfig configures the secure (coaps) listeners. The listeners are created only when ExternalAddresses are set.
+type DTLSConfig struct {
+	ExternalAddresses []string `yaml:"externalAddresses"`
+	// Certificate is used for the certificate based cipher suites.
+	Certificate *tls.Certificate `yaml:"-"`
+	// CAPool verifies the certificates of the clients, when it is set the client certificate is required. Otherwise the client
+	// certificate is only requested and it is verified by the CAs provisioned to the target device.
+	CAPool *x509.CertPool `yaml:"-"`
+	// PSK returns the pre-shared key for the identity of the client. The deviceID is the device of the listener which
+	// accepted the connection, it is uuid.Nil for the listeners shared by all bridged devices.
+	PSK func(deviceID uuid.UUID, identity []byte) ([]byte, error) `yaml:"-"`
+	// JustWorks enables the anonymous cipher suite used by the just works ownership transfer method.
+	JustWorks bool `yaml:"justWorks"`
+	// AllowJustWorks returns whether the anonymous cipher suite is offered on the listener of the device, it restricts
+	// the cipher suite to the ownership transfer of the device. When it is nil, the cipher suite is always offered.
+	AllowJustWorks        func(deviceID uuid.UUID) bool `yaml:"-"`
+	externalAddressesPort externalAddressesPort         `yaml:"-"`
+}
+
+func (cfg *DTLSConfig) Enabled() bool {
+	return len(cfg.ExternalAddresses) > 0
+}
+
+func (cfg *DTLSConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.Certificate == nil && cfg.PSK == nil && !cfg.JustWorks {
+		return errors.New("certificate, PSK or justWorks is required")
+	}
+	externalAddressesPort, err := validateExternalAddresses(cfg.ExternalAddresses, false)
+	if err != nil {
+		return err
+	}
+	cfg.externalAddressesPort = externalAddressesPort
+	return nil
+}
+
+// toDTLSConfig creates the configuration of the connection accepted by the listener of the device.
+func (cfg *DTLSConfig) toDTLSConfig(deviceID uuid.UUID) *dtls.Config {
+	c := dtls.Config{}
+	if cfg.Certificate != nil {
+		c.Certificates = []tls.Certificate{*cfg.Certificate}
+		c.CipherSuites = append(c.CipherSuites, dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM_8, dtls.TLS_ECDHE_ECDSA_WITH_AES_128_CCM)
+	}
+	if cfg.CAPool != nil {
+		c.ClientCAs = cfg.CAPool
+		c.ClientAuth = dtls.RequireAndVerifyClientCert
+	} else if cfg.Certificate != nil {
+		c.ClientAuth = dtls.RequestClientCert
+	}
+	if cfg.PSK != nil {
+		c.PSK = func(identity []byte) ([]byte, error) {
+			return cfg.PSK(deviceID, identity)
+		}
+		c.CipherSuites = append(c.CipherSuites, dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256, dtls.TLS_PSK_WITH_AES_128_CCM_8)
+	}
+	if cfg.JustWorks && (cfg.AllowJustWorks == nil || cfg.AllowJustWorks(deviceID)) {
+		c.CustomCipherSuites = func() []dtls.CipherSuite {
+			return []dtls.CipherSuite{cipher.NewTLSAecdhAes128Sha256(justWorksCipherSuiteID)}
+		}
+	}
+	return &c
+}
+
+// TCPConfig configures the unsecure CoAP over TCP (coap+tcp) listeners. The listeners are created only when ExternalAddresses are set.
+type TCPConfig struct {
+	ExternalAddresses     []string              `yaml:"externalAddresses"`
+	externalAddressesPort externalAddressesPort `yaml:"-"`
+}
+
+func (cfg *TCPConfig) Enabled() bool {
+	return len(cfg.ExternalAddresses) > 0
+}
+
+func (cfg *TCPConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	externalAddressesPort, err := validateExternalAddresses(cfg.ExternalAddresses, true)
+	if err != nil {
+		return err
+	}
+	cfg.externalAddressesPort = externalAddressesPort
+	return nil
+}
+
+// TLSConfig configures the secure CoAP over TCP (coaps+tcp) listeners. The listeners are created only when ExternalAddresses are set.
+type TLSConfig struct {
+	ExternalAddresses []string `yaml:"externalAddresses"`
+	// Certificate of the server, it is required because TLS doesn't support the pre-shared key cipher suites.
+	Certificate *tls.Certificate `yaml:"-"`
+	// CAPool verifies the certificates of the clients, when it is set the client certificate is required. Otherwise the client
+	// certificate is only requested and it is verified by the CAs provisioned to the target device.
+	CAPool                *x509.CertPool        `yaml:"-"`
+	externalAddressesPort externalAddressesPort `yaml:"-"`
+}
+
+func (cfg *TLSConfig) Enabled() bool {
+	return len(cfg.ExternalAddresses) > 0
+}
+
+func (cfg *TLSConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.Certificate == nil {
+		return errors.New("certificate is required")
+	}
+	externalAddressesPort, err := validateExternalAddresses(cfg.ExternalAddresses, true)
+	if err != nil {
+		return err
+	}
+	cfg.externalAddressesPort = externalAddressesPort
+	return nil
+}
+
+func (cfg *TLSConfig) toTLSConfig() *tls.Config {
+	c := tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*cfg.Certificate},
+	}
+	if cfg.CAPool != nil {
+		c.ClientCAs = cfg.CAPool
+		c.ClientAuth = tls.RequireAndVerifyClientCert
+	} else {
+		c.ClientAuth = tls.RequestClientCert
+	}
+	return &c
+}
+
+// justWorksCipherSuiteID is the ID of the anonymous cipher suite used by the just works ownership transfer method.
+const justWorksCipherSuiteID = dtls.CipherSuiteID(0xff00)
+
 type Config struct {
 	ExternalAddresses     []string              `yaml:"externalAddresses"`
 	MaxMessageSize        uint32                `yaml:"maxMessageSize"`
 	DeduplicationLifetime time.Duration         `yaml:"deduplicationLifetime"`
+	DTLS                  DTLSConfig            `yaml:"dtls"`
+	TCP                   TCPConfig             `yaml:"tcp"`
+	TLS                   TLSConfig             `yaml:"tls"`
 	externalAddressesPort externalAddressesPort `yaml:"-"`
 }
 
@@ -109,6 +253,29 @@ func validateExternalAddress(addr string) (externalAddressPort, error) {
 	}, nil
 }
 
+// toTCPNetwork converts the UDP network of the external address to the TCP network of the same IP version.
+func toTCPNetwork(network string) string {
+	if network == UDP6 {
+		return TCP6
+	}
+	return TCP4
+}
+
+func validateExternalAddresses(addresses []string, tcp bool) (externalAddressesPort, error) {
+	externalAddressesPort := make([]externalAddressPort, 0, len(addresses))
+	for i, e := range addresses {
+		extAddress, err := validateExternalAddress(e)
+		if err != nil {
+			return nil, fmt.Errorf("externalAddresses[%v:%v]: %w", i, e, err)
+		}
+		if tcp {
+			extAddress.network = toTCPNetwork(extAddress.network)
+		}
+		externalAddressesPort = append(externalAddressesPort, extAddress)
+	}
+	return externalAddressesPort, nil
+}
+
 func (cfg *Config) Validate() error {
 	if len(cfg.ExternalAddresses) == 0 {
 		return fmt.Errorf("%w: cannot be empty", ErrInvalidExternalAddress)
@@ -128,5 +295,14 @@ func (cfg *Config) Validate() error {
 		externalAddressesPort = append(externalAddressesPort, extAddress)
 	}
 	cfg.externalAddressesPort = externalAddressesPort
+	if err := cfg.DTLS.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration dtls.%w", err)
+	}
+	if err := cfg.TCP.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration tcp.%w", err)
+	}
+	if err := cfg.TLS.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration tls.%w", err)
+	}
 	return nil
 }
@@ -21,6 +21,7 @@ package net
 import (
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -110,6 +111,74 @@ func TestConfigValidate(t *testing.T) {
 			config:  &Config{ExternalAddresses: []string{"localhost:invalid"}},
 			wantErr: true,
 		},
+		{
+			name: "DTLSWithoutCredentials",
+			config: &Config{
+				ExternalAddresses: []string{"localhost:12345"},
+				DTLS:              DTLSConfig{ExternalAddresses: []string{"localhost:12346"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "DTLSJustWorks",
+			config: &Config{
+				ExternalAddresses: []string{"localhost:12345"},
+				DTLS: DTLSConfig{
+					ExternalAddresses: []string{"localhost:12346"},
+					JustWorks:         true,
+				},
+			},
+			want: data{
+				maxMsgSize: DefaultMaxMessageSize,
+				externalAddressesPort: externalAddressesPort{{
+					host:    "localhost",
+					port:    "12345",
+					network: UDP4,
+				}},
+			},
+		},
+		{
+			name: "DTLSInvalidExternalAddress",
+			config: &Config{
+				ExternalAddresses: []string{"localhost:12345"},
+				DTLS: DTLSConfig{
+					ExternalAddresses: []string{"invalid-address"},
+					PSK:               func(uuid.UUID, []byte) ([]byte, error) { return nil, nil },
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TCP",
+			config: &Config{
+				ExternalAddresses: []string{"localhost:12345"},
+				TCP:               TCPConfig{ExternalAddresses: []string{"localhost:12346"}},
+			},
+			want: data{
+				maxMsgSize: DefaultMaxMessageSize,
+				externalAddressesPort: externalAddressesPort{{
+					host:    "localhost",
+					port:    "12345",
+					network: UDP4,
+				}},
+			},
+		},
+		{
+			name: "TCPInvalidExternalAddress",
+			config: &Config{
+				ExternalAddresses: []string{"localhost:12345"},
+				TCP:               TCPConfig{ExternalAddresses: []string{"invalid-address"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TLSWithoutCertificate",
+			config: &Config{
+				ExternalAddresses: []string{"localhost:12345"},
+				TLS:               TLSConfig{ExternalAddresses: []string{"localhost:12347"}},
+			},
+			wantErr: true,
+		},
 		{
 			name:    "PortGreaterThanMaxUint16",
 			config:  &Config{ExternalAddresses: []string{"localhost:65536"}},
@@ -127,6 +196,9 @@ func TestConfigValidate(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, tt.want.maxMsgSize, tt.config.MaxMessageSize)
 			require.Equal(t, tt.want.externalAddressesPort, tt.config.externalAddressesPort)
+			for _, e := range tt.config.TCP.externalAddressesPort {
+				require.Contains(t, []string{TCP4, TCP6}, e.network)
+			}
 		})
 	}
 }
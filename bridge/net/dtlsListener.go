@@ -0,0 +1,111 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package net
+
+import (
+	"context"
+	"fmt"
+	gonet "net"
+	"sync/atomic"
+
+	"github.com/pion/dtls/v3"
+	dtlsNet "github.com/pion/dtls/v3/pkg/net"
+	"github.com/pion/dtls/v3/pkg/protocol"
+	"github.com/pion/dtls/v3/pkg/protocol/recordlayer"
+	"github.com/pion/transport/v3/udp"
+	"github.com/plgd-dev/go-coap/v3/net"
+)
+
+// dtlsListener accepts the DTLS connections with the configuration created for each connection, so the cipher
+// suites offered to the client follow the actual state of the device, e.g. the just works cipher suite is offered
+// only during the ownership transfer.
+type dtlsListener struct {
+	listener  gonet.Listener
+	getConfig func() *dtls.Config
+	closed    atomic.Bool
+}
+
+func isHandshake(datagram []byte) bool {
+	pkts, err := recordlayer.UnpackDatagram(datagram)
+	if err != nil || len(pkts) < 1 {
+		return false
+	}
+	var h recordlayer.Header
+	if err = h.Unmarshal(pkts[0]); err != nil {
+		return false
+	}
+	return h.ContentType == protocol.ContentTypeHandshake
+}
+
+func newDTLSListener(network, addr string, getConfig func() *dtls.Config) (*dtlsListener, error) {
+	a, err := gonet.ResolveUDPAddr(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve address: %w", err)
+	}
+	lc := udp.ListenConfig{
+		AcceptFilter: isHandshake,
+	}
+	l, err := lc.Listen(network, a)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create new dtls listener: %w", err)
+	}
+	return &dtlsListener{
+		listener:  l,
+		getConfig: getConfig,
+	}, nil
+}
+
+func (l *dtlsListener) newConn(c gonet.Conn) (gonet.Conn, error) {
+	conn, err := dtls.Server(dtlsNet.PacketConnFromConn(c), c.RemoteAddr(), l.getConfig())
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// AcceptWithContext waits with context for a generic Conn.
+func (l *dtlsListener) AcceptWithContext(ctx context.Context) (gonet.Conn, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+	if l.closed.Load() {
+		return nil, net.ErrListenerIsClosed
+	}
+	c, err := l.listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return l.newConn(c)
+}
+
+// Close closes the listener.
+func (l *dtlsListener) Close() error {
+	if !l.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	return l.listener.Close()
+}
+
+// Addr represents a network end point address.
+func (l *dtlsListener) Addr() gonet.Addr {
+	return l.listener.Addr()
+}
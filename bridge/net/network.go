@@ -21,6 +21,7 @@ package net
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -30,12 +31,16 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
+	pionDTLS "github.com/pion/dtls/v3"
 	"github.com/plgd-dev/device/v2/client/core"
 	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
 	"github.com/plgd-dev/device/v2/pkg/codec/json"
 	"github.com/plgd-dev/device/v2/pkg/log"
 	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/dtls"
+	dtlsServer "github.com/plgd-dev/go-coap/v3/dtls/server"
 	"github.com/plgd-dev/go-coap/v3/message"
 	"github.com/plgd-dev/go-coap/v3/message/codes"
 	"github.com/plgd-dev/go-coap/v3/message/pool"
@@ -44,6 +49,8 @@ import (
 	"github.com/plgd-dev/go-coap/v3/net"
 	"github.com/plgd-dev/go-coap/v3/options"
 	coapCache "github.com/plgd-dev/go-coap/v3/pkg/cache"
+	"github.com/plgd-dev/go-coap/v3/tcp"
+	tcpServer "github.com/plgd-dev/go-coap/v3/tcp/server"
 	"github.com/plgd-dev/go-coap/v3/udp"
 	"github.com/plgd-dev/go-coap/v3/udp/server"
 )
@@ -60,6 +67,17 @@ type Net struct {
 	wg      sync.WaitGroup
 	done    chan struct{}
 	cache   *coapCache.Cache[int32, bool]
+
+	devicesLock sync.Mutex
+	devices     map[uuid.UUID]*deviceServers
+}
+
+// deviceServers are the servers listening on the endpoints of a single device.
+type deviceServers struct {
+	servers                   coAPServers
+	externalAddressesPort     externalAddressesPort
+	dtlsExternalAddressesPort externalAddressesPort
+	wg                        sync.WaitGroup
 }
 
 func newMCastConn(multicastAddr string, logger log.Logger) (*net.UDPConn, error) {
@@ -192,6 +210,12 @@ func CreateLoggingMiddleware(logger log.Logger) func(next mux.Handler) mux.Handl
 }
 
 func (n *Net) ServeCOAP(w mux.ResponseWriter, request *mux.Message) {
+	n.serveCOAP(w, request, uuid.Nil)
+}
+
+// serveCOAP handles the request received on the listeners of the device, the deviceID is uuid.Nil for the
+// listeners shared by all devices.
+func (n *Net) serveCOAP(w mux.ResponseWriter, request *mux.Message, deviceID uuid.UUID) {
 	now := time.Now()
 	messageID := request.MessageID()
 	if messageID >= 0 && request.Type() != message.Confirmable {
@@ -206,9 +230,11 @@ func (n *Net) ServeCOAP(w mux.ResponseWriter, request *mux.Message) {
 	request.Hijack()
 	go func(w mux.ResponseWriter, request *mux.Message) {
 		r := Request{
-			Message:   request.Message,
-			Endpoints: n.GetEndpoints(request.ControlMessage(), w.Conn().NetConn().LocalAddr().String()),
-			Conn:      w.Conn(),
+			Message:          request.Message,
+			Endpoints:        n.GetDeviceEndpoints(deviceID, request.ControlMessage(), w.Conn().NetConn().LocalAddr().String()),
+			Conn:             w.Conn(),
+			Peer:             getPeerIdentity(w.Conn().NetConn()),
+			ListenerDeviceID: deviceID,
 		}
 
 		resp, err := n.handler(&r)
@@ -225,23 +251,62 @@ func (n *Net) ServeCOAP(w mux.ResponseWriter, request *mux.Message) {
 	}(w, request)
 }
 
-type coAPServer struct {
+type coAPServer interface {
+	Serve() error
+	Stop()
+	Close() error
+}
+
+type udpCoAPServer struct {
 	s *server.Server
 	l *net.UDPConn
 }
 
+func (s udpCoAPServer) Serve() error { return s.s.Serve(s.l) }
+
+func (s udpCoAPServer) Stop() { s.s.Stop() }
+
+func (s udpCoAPServer) Close() error { return s.l.Close() }
+
+type dtlsCoAPServer struct {
+	s *dtlsServer.Server
+	l *dtlsListener
+}
+
+func (s dtlsCoAPServer) Serve() error { return s.s.Serve(s.l) }
+
+func (s dtlsCoAPServer) Stop() { s.s.Stop() }
+
+func (s dtlsCoAPServer) Close() error { return s.l.Close() }
+
+type tcpListener interface {
+	tcpServer.Listener
+	Addr() gonet.Addr
+}
+
+type tcpCoAPServer struct {
+	s *tcpServer.Server
+	l tcpListener
+}
+
+func (s tcpCoAPServer) Serve() error { return s.s.Serve(s.l) }
+
+func (s tcpCoAPServer) Stop() { s.s.Stop() }
+
+func (s tcpCoAPServer) Close() error { return s.l.Close() }
+
 type coAPServers []coAPServer
 
 func (s coAPServers) Stop() {
 	for _, cs := range s {
-		cs.s.Stop()
+		cs.Stop()
 	}
 }
 
 func (s coAPServers) Close() error {
 	var errors *multierror.Error
 	for _, cs := range s {
-		err := cs.l.Close()
+		err := cs.Close()
 		if err != nil {
 			errors = multierror.Append(errors, err)
 		}
@@ -262,51 +327,118 @@ func getPortFromAddress(addr gonet.Addr) (string, error) {
 	return port, nil
 }
 
-func newServers(cfg *Config, m *mux.Router, logger log.Logger) (coAPServers, bool, bool, error) {
-	servers := make(coAPServers, 0, len(cfg.externalAddressesPort))
-	hasIPv4 := false
-	hasIPv6 := false
-	for i, addr := range cfg.externalAddressesPort {
-		var conn *net.UDPConn
-		var err error
-		if addr.network == UDP4 {
-			hasIPv4 = true
-			conn, err = newConn(addr.network, addr.port)
-		}
-		if addr.network == UDP6 {
-			hasIPv6 = true
-			conn, err = newConn(addr.network, addr.port)
-		}
+func appendUDPServers(servers coAPServers, externalAddressesPort externalAddressesPort, maxMessageSize uint32, m *mux.Router, logger log.Logger) (coAPServers, error) {
+	for i, addr := range externalAddressesPort {
+		conn, err := newConn(addr.network, addr.port)
 		if err != nil {
 			_ = servers.Close()
-			return nil, false, false, err
+			return nil, err
 		}
 		if addr.port == "0" {
 			port, err := getPortFromAddress(conn.LocalAddr())
 			if err != nil {
+				_ = conn.Close()
 				_ = servers.Close()
-				return nil, false, false, err
+				return nil, err
 			}
-			cfg.externalAddressesPort[i].port = port
+			externalAddressesPort[i].port = port
 		}
+		servers = append(servers, udpCoAPServer{
+			s: udp.NewServer(
+				options.WithMux(m),
+				options.WithErrors(func(err error) { logger.Errorf("server: %w", err) }),
+				options.WithMaxMessageSize(maxMessageSize),
+			),
+			l: conn,
+		})
+	}
+	return servers, nil
+}
 
-		if conn != nil {
-			servers = append(servers, coAPServer{
-				s: udp.NewServer(
-					options.WithMux(m),
-					options.WithErrors(func(err error) { logger.Errorf("server: %w", err) }),
-					options.WithMaxMessageSize(cfg.MaxMessageSize),
-				),
-				l: conn,
-			})
-		}
+func newServers(cfg *Config, m *mux.Router, logger log.Logger) (coAPServers, bool, bool, error) {
+	servers, err := appendUDPServers(make(coAPServers, 0, len(cfg.externalAddressesPort)), cfg.externalAddressesPort, cfg.MaxMessageSize, m, logger)
+	if err != nil {
+		return nil, false, false, err
 	}
 	if len(servers) == 0 {
 		return nil, false, false, errors.New("cannot create any server")
 	}
+	hasIPv4 := len(cfg.externalAddressesPort.filterByNetwork(UDP4)) > 0
+	hasIPv6 := len(cfg.externalAddressesPort.filterByNetwork(UDP6)) > 0
 	return servers, hasIPv4, hasIPv6, nil
 }
 
+// appendDTLSServers appends the coaps servers of the device, the deviceID is uuid.Nil for the servers shared by all devices.
+func appendDTLSServers(servers coAPServers, cfg *DTLSConfig, externalAddressesPort externalAddressesPort, deviceID uuid.UUID, maxMessageSize uint32, m *mux.Router, logger log.Logger) (coAPServers, error) {
+	for i, addr := range externalAddressesPort {
+		l, err := newDTLSListener(addr.network, ":"+addr.port, func() *pionDTLS.Config {
+			return cfg.toDTLSConfig(deviceID)
+		})
+		if err != nil {
+			_ = servers.Close()
+			return nil, err
+		}
+		if addr.port == "0" {
+			port, err := getPortFromAddress(l.Addr())
+			if err != nil {
+				_ = l.Close()
+				_ = servers.Close()
+				return nil, err
+			}
+			externalAddressesPort[i].port = port
+		}
+		servers = append(servers, dtlsCoAPServer{
+			s: dtls.NewServer(
+				options.WithMux(m),
+				options.WithErrors(func(err error) { logger.Errorf("dtls server: %w", err) }),
+				options.WithMaxMessageSize(maxMessageSize),
+			),
+			l: l,
+		})
+	}
+	return servers, nil
+}
+
+func newTCPListener(network, port string, tlsCfg *tls.Config) (tcpListener, error) {
+	if tlsCfg != nil {
+		return net.NewTLSListener(network, ":"+port, tlsCfg)
+	}
+	return net.NewTCPListener(network, ":"+port)
+}
+
+// appendTCPServers appends the coap+tcp servers or the coaps+tcp servers when tlsCfg is set.
+func appendTCPServers(servers coAPServers, externalAddressesPort externalAddressesPort, tlsCfg *tls.Config, maxMessageSize uint32, m *mux.Router, logger log.Logger) (coAPServers, error) {
+	serverName := "tcp server"
+	if tlsCfg != nil {
+		serverName = "tls server"
+	}
+	for i, addr := range externalAddressesPort {
+		l, err := newTCPListener(addr.network, addr.port, tlsCfg)
+		if err != nil {
+			_ = servers.Close()
+			return nil, err
+		}
+		if addr.port == "0" {
+			port, err := getPortFromAddress(l.Addr())
+			if err != nil {
+				_ = l.Close()
+				_ = servers.Close()
+				return nil, err
+			}
+			externalAddressesPort[i].port = port
+		}
+		servers = append(servers, tcpCoAPServer{
+			s: tcp.NewServer(
+				options.WithMux(m),
+				options.WithErrors(func(err error) { logger.Errorf("%v: %w", serverName, err) }),
+				options.WithMaxMessageSize(maxMessageSize),
+			),
+			l: l,
+		})
+	}
+	return servers, nil
+}
+
 func appendMCastServers(servers coAPServers, mcastAddresses []string, cfg Config, m *mux.Router, logger log.Logger) (coAPServers, error) {
 	for _, addr := range mcastAddresses {
 		if addr == "" {
@@ -317,7 +449,7 @@ func appendMCastServers(servers coAPServers, mcastAddresses []string, cfg Config
 			_ = servers.Close()
 			return nil, err
 		}
-		servers = append(servers, coAPServer{
+		servers = append(servers, udpCoAPServer{
 			s: udp.NewServer(options.WithMux(m),
 				options.WithMaxMessageSize(cfg.MaxMessageSize),
 				options.WithErrors(func(err error) { logger.Errorf("mcast server: %w", err) }),
@@ -338,6 +470,24 @@ func New(cfg Config, handler RequestHandler, logger log.Logger) (*Net, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cfg.DTLS.Enabled() {
+		servers, err = appendDTLSServers(servers, &cfg.DTLS, cfg.DTLS.externalAddressesPort, uuid.Nil, cfg.MaxMessageSize, m, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.TCP.Enabled() {
+		servers, err = appendTCPServers(servers, cfg.TCP.externalAddressesPort, nil, cfg.MaxMessageSize, m, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.TLS.Enabled() {
+		servers, err = appendTCPServers(servers, cfg.TLS.externalAddressesPort, cfg.TLS.toTLSConfig(), cfg.MaxMessageSize, m, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if hasIPv4 {
 		servers, err = appendMCastServers(servers, core.DefaultDiscoveryConfiguration().MulticastAddressUDP4, cfg, m, logger)
 		if err != nil {
@@ -359,6 +509,7 @@ func New(cfg Config, handler RequestHandler, logger log.Logger) (*Net, error) {
 		logger:  logger,
 		done:    make(chan struct{}),
 		cache:   coapCache.NewCache[int32, bool](),
+		devices: make(map[uuid.UUID]*deviceServers),
 	}
 	m.DefaultHandle(mux.HandlerFunc(n.ServeCOAP))
 	n.wg.Add(1)
@@ -377,14 +528,14 @@ func New(cfg Config, handler RequestHandler, logger log.Logger) (*Net, error) {
 	return n, nil
 }
 
-func (n *Net) getNetwork(cm *net.ControlMessage, localHost, localPort string) string {
+func getNetwork(externalAddressesPort externalAddressesPort, cm *net.ControlMessage, localHost, localPort string) string {
 	if cm != nil {
 		if cm.Dst.To4() == nil {
 			return UDP6
 		}
 		return UDP4
 	}
-	p := n.cfg.externalAddressesPort.filterByPort(localPort)
+	p := externalAddressesPort.filterByPort(localPort)
 	if len(p) == 1 {
 		return p[0].network
 	}
@@ -395,31 +546,70 @@ func (n *Net) getNetwork(cm *net.ControlMessage, localHost, localPort string) st
 	return UDP4
 }
 
+func getEndpointAddress(externalAddressesPort externalAddressesPort, network, localPort string) (string, bool) {
+	filteredByNetwork := externalAddressesPort.filterByNetwork(network)
+	filtered := filteredByNetwork.filterByPort(localPort)
+	if len(filtered) == 0 {
+		filtered = filteredByNetwork
+	}
+	if len(filtered) == 0 {
+		return "", false
+	}
+	ep := filtered[0].host
+	if filtered[0].network == UDP6 || filtered[0].network == TCP6 {
+		ep = "[" + ep + "]"
+	}
+	return ep + ":" + filtered[0].port, true
+}
+
 func (n *Net) GetEndpoints(cm *net.ControlMessage, localAddr string) schema.Endpoints {
+	return n.getEndpoints(n.cfg.externalAddressesPort, n.cfg.DTLS.externalAddressesPort, cm, localAddr)
+}
+
+// GetDeviceEndpoints returns the endpoints of the device for the request received on the local address. The coap and
+// coaps endpoints are the listeners of the device created by ListenDevice, otherwise the endpoints are shared by all devices.
+func (n *Net) GetDeviceEndpoints(deviceID uuid.UUID, cm *net.ControlMessage, localAddr string) schema.Endpoints {
+	n.devicesLock.Lock()
+	d, ok := n.devices[deviceID]
+	n.devicesLock.Unlock()
+	if !ok {
+		return n.GetEndpoints(cm, localAddr)
+	}
+	return n.getEndpoints(d.externalAddressesPort, d.dtlsExternalAddressesPort, cm, localAddr)
+}
+
+func (n *Net) getEndpoints(udpAddressesPort, dtlsAddressesPort externalAddressesPort, cm *net.ControlMessage, localAddr string) schema.Endpoints {
 	localHost, localPort, err := gonet.SplitHostPort(localAddr)
 	if err != nil {
 		n.logger.Warnf("cannot get local address: %v", err)
 		return nil
 	}
-	network := n.getNetwork(cm, localHost, localPort)
-	filteredByNetwork := n.cfg.externalAddressesPort.filterByNetwork(network)
-	filtered := filteredByNetwork.filterByPort(localPort)
-	if len(filtered) == 0 {
-		filtered = filteredByNetwork
-	}
-	ep := localAddr
-	if len(filtered) > 0 {
-		ep = filtered[0].host
-		if filtered[0].network == UDP6 {
-			ep = "[" + ep + "]"
-		}
-		ep = ep + ":" + filtered[0].port
+	network := getNetwork(udpAddressesPort, cm, localHost, localPort)
+	ep, ok := getEndpointAddress(udpAddressesPort, network, localPort)
+	if !ok {
+		ep = localAddr
 	}
-	return schema.Endpoints{
+	endpoints := schema.Endpoints{
 		{
-			URI: fmt.Sprintf("coap://%v", ep),
+			URI: fmt.Sprintf("%v://%v", schema.UDPScheme, ep),
 		},
 	}
+	if secureEp, ok := getEndpointAddress(dtlsAddressesPort, network, localPort); ok {
+		endpoints = append(endpoints, schema.Endpoint{
+			URI: fmt.Sprintf("%v://%v", schema.UDPSecureScheme, secureEp),
+		})
+	}
+	if tcpEp, ok := getEndpointAddress(n.cfg.TCP.externalAddressesPort, toTCPNetwork(network), localPort); ok {
+		endpoints = append(endpoints, schema.Endpoint{
+			URI: fmt.Sprintf("%v://%v", schema.TCPScheme, tcpEp),
+		})
+	}
+	if tlsEp, ok := getEndpointAddress(n.cfg.TLS.externalAddressesPort, toTCPNetwork(network), localPort); ok {
+		endpoints = append(endpoints, schema.Endpoint{
+			URI: fmt.Sprintf("%v://%v", schema.TCPSecureScheme, tlsEp),
+		})
+	}
+	return endpoints
 }
 
 func (n *Net) Serve() error {
@@ -435,7 +625,7 @@ func (n *Net) Serve() error {
 	for _, cs := range n.servers {
 		go func(cs coAPServer) {
 			defer wg.Done()
-			err := cs.s.Serve(cs.l)
+			err := cs.Serve()
 			errCh <- err
 		}(cs)
 	}
@@ -453,12 +643,98 @@ func (n *Net) Serve() error {
 	}
 }
 
+// withPort returns a copy of the addresses with the port.
+func (extAddresses externalAddressesPort) withPort(port string) externalAddressesPort {
+	addresses := make(externalAddressesPort, 0, len(extAddresses))
+	for _, e := range extAddresses {
+		e.port = port
+		addresses = append(addresses, e)
+	}
+	return addresses
+}
+
+// ListenDevice creates the coap and coaps listeners of the device on the ports chosen by the system, the requests
+// received by them are handled for the device without the di query. The listeners are announced by the endpoints of
+// the device, so the client which doesn't know the device is bridged communicates with the device as with any other,
+// e.g. the coaps listener selects the pre-shared key and offers the just works cipher suite for the device.
+func (n *Net) ListenDevice(deviceID uuid.UUID) error {
+	n.devicesLock.Lock()
+	defer n.devicesLock.Unlock()
+	if n.stopped.Load() {
+		return errors.New("already stopped")
+	}
+	if _, ok := n.devices[deviceID]; ok {
+		return fmt.Errorf("listeners of device %v already exist", deviceID)
+	}
+	m := mux.NewRouter()
+	m.DefaultHandle(mux.HandlerFunc(func(w mux.ResponseWriter, r *mux.Message) {
+		n.serveCOAP(w, r, deviceID)
+	}))
+	d := &deviceServers{
+		externalAddressesPort: n.cfg.externalAddressesPort.withPort("0"),
+	}
+	servers, err := appendUDPServers(make(coAPServers, 0, len(d.externalAddressesPort)), d.externalAddressesPort, n.cfg.MaxMessageSize, m, n.logger)
+	if err != nil {
+		return err
+	}
+	if n.cfg.DTLS.Enabled() {
+		d.dtlsExternalAddressesPort = n.cfg.DTLS.externalAddressesPort.withPort("0")
+		servers, err = appendDTLSServers(servers, &n.cfg.DTLS, d.dtlsExternalAddressesPort, deviceID, n.cfg.MaxMessageSize, m, n.logger)
+		if err != nil {
+			return err
+		}
+	}
+	d.servers = servers
+	d.wg.Add(len(servers))
+	for _, cs := range servers {
+		go func(cs coAPServer) {
+			defer d.wg.Done()
+			if err := cs.Serve(); err != nil {
+				n.logger.Errorf("device %v: %w", deviceID, err)
+			}
+		}(cs)
+	}
+	n.devices[deviceID] = d
+	return nil
+}
+
+func (d *deviceServers) close() {
+	d.servers.Stop()
+	d.wg.Wait()
+	// the listeners are already closed by the stopped servers, unless they were stopped before serving
+	_ = d.servers.Close()
+}
+
+// CloseDevice closes the listeners of the device created by ListenDevice.
+func (n *Net) CloseDevice(deviceID uuid.UUID) error {
+	n.devicesLock.Lock()
+	d, ok := n.devices[deviceID]
+	delete(n.devices, deviceID)
+	n.devicesLock.Unlock()
+	if !ok {
+		return fmt.Errorf("listeners of device %v not found", deviceID)
+	}
+	d.close()
+	return nil
+}
+
+func (n *Net) closeDevices() {
+	n.devicesLock.Lock()
+	devices := n.devices
+	n.devices = make(map[uuid.UUID]*deviceServers)
+	n.devicesLock.Unlock()
+	for _, d := range devices {
+		d.close()
+	}
+}
+
 func (n *Net) Close() error {
 	if !n.stopped.CompareAndSwap(false, true) {
 		return nil
 	}
 	close(n.done)
 	n.wg.Wait()
+	n.closeDevices()
 	if !n.serving.Load() {
 		return n.servers.Close()
 	}
@@ -19,10 +19,31 @@
 package net
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
 	"net"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/pion/dtls/v3"
+	"github.com/plgd-dev/device/v2/client/core/otm/just-works/cipher"
 	"github.com/plgd-dev/device/v2/pkg/log"
+	"github.com/plgd-dev/device/v2/schema"
+	coapDtls "github.com/plgd-dev/go-coap/v3/dtls"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/plgd-dev/go-coap/v3/options"
+	"github.com/plgd-dev/go-coap/v3/tcp"
+	"github.com/plgd-dev/go-coap/v3/udp"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,17 +75,13 @@ func TestGetNetwork(t *testing.T) {
 	err := cfg.Validate()
 	require.NoError(t, err)
 
-	n := &Net{
-		cfg: cfg,
-	}
-
-	network := n.getNetwork(nil, "127.0.0.1", "42")
+	network := getNetwork(cfg.externalAddressesPort, nil, "127.0.0.1", "42")
 	require.Equal(t, UDP4, network)
-	network = n.getNetwork(nil, "[::1]", "42")
+	network = getNetwork(cfg.externalAddressesPort, nil, "[::1]", "42")
 	require.Equal(t, UDP6, network)
-	network = n.getNetwork(nil, "127.0.0.1", "13")
+	network = getNetwork(cfg.externalAddressesPort, nil, "127.0.0.1", "13")
 	require.Equal(t, UDP4, network)
-	network = n.getNetwork(nil, "[::1]", "37")
+	network = getNetwork(cfg.externalAddressesPort, nil, "[::1]", "37")
 	require.Equal(t, UDP6, network)
 }
 
@@ -94,3 +111,392 @@ func TestClose(t *testing.T) {
 	require.NoError(t, err)
 	checkUDPPort(false)
 }
+
+func TestGetEndpointsWithDTLS(t *testing.T) {
+	cfg := Config{
+		ExternalAddresses: []string{"127.0.0.1:42"},
+		DTLS: DTLSConfig{
+			ExternalAddresses: []string{"127.0.0.1:43"},
+			PSK: func(uuid.UUID, []byte) ([]byte, error) {
+				return []byte("key"), nil
+			},
+		},
+	}
+	err := cfg.Validate()
+	require.NoError(t, err)
+	n := &Net{
+		cfg:    cfg,
+		logger: log.NewNilLogger(),
+	}
+	require.Equal(t, schema.Endpoints{
+		{URI: "coap://127.0.0.1:42"},
+		{URI: "coaps://127.0.0.1:43"},
+	}, n.GetEndpoints(nil, "127.0.0.1:43"))
+}
+
+func TestListenDevice(t *testing.T) {
+	requests := make(chan *Request, 1)
+	cfg := Config{
+		ExternalAddresses: []string{"127.0.0.1:0"},
+		DTLS: DTLSConfig{
+			ExternalAddresses: []string{"127.0.0.1:0"},
+			JustWorks:         true,
+		},
+	}
+	n, err := New(cfg, func(req *Request) (*pool.Message, error) {
+		requests <- req
+		resp := pool.NewMessage(req.Context())
+		resp.SetCode(codes.Content)
+		return resp, nil
+	}, log.NewNilLogger())
+	require.NoError(t, err)
+	go func() {
+		_ = n.Serve()
+	}()
+	defer func() {
+		errC := n.Close()
+		require.NoError(t, errC)
+	}()
+
+	deviceID := uuid.New()
+	err = n.ListenDevice(deviceID)
+	require.NoError(t, err)
+	err = n.ListenDevice(deviceID)
+	require.Error(t, err)
+
+	d := n.devices[deviceID]
+	udpAddr := "127.0.0.1:" + d.externalAddressesPort[0].port
+	dtlsAddr := "127.0.0.1:" + d.dtlsExternalAddressesPort[0].port
+	require.NotEqual(t, n.cfg.externalAddressesPort[0].port, d.externalAddressesPort[0].port)
+	require.NotEqual(t, n.cfg.DTLS.externalAddressesPort[0].port, d.dtlsExternalAddressesPort[0].port)
+	sharedAddr := "127.0.0.1:" + n.cfg.externalAddressesPort[0].port
+	require.Equal(t, schema.Endpoints{
+		{URI: "coap://" + udpAddr},
+		{URI: "coaps://" + dtlsAddr},
+	}, n.GetDeviceEndpoints(deviceID, nil, sharedAddr))
+	require.Equal(t, n.GetEndpoints(nil, sharedAddr), n.GetDeviceEndpoints(uuid.New(), nil, sharedAddr))
+
+	// the request without the di query is handled for the device of the listener
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	conn, err := udp.Dial(udpAddr)
+	require.NoError(t, err)
+	resp, err := conn.Get(ctx, "/test")
+	require.NoError(t, err)
+	require.Equal(t, codes.Content, resp.Code())
+	req := <-requests
+	require.Equal(t, deviceID, req.ListenerDeviceID)
+	require.Equal(t, deviceID, req.DeviceID())
+	require.Contains(t, req.Endpoints, schema.Endpoint{URI: "coap://" + udpAddr})
+	_ = conn.Close()
+
+	err = n.CloseDevice(deviceID)
+	require.NoError(t, err)
+	err = n.CloseDevice(deviceID)
+	require.Error(t, err)
+	listenAddress, err := net.ResolveUDPAddr(UDP4, udpAddr)
+	require.NoError(t, err)
+	l, err := net.ListenUDP(UDP4, listenAddress)
+	require.NoError(t, err)
+	_ = l.Close()
+}
+
+func TestDTLSPeerIdentity(t *testing.T) {
+	psks := map[uuid.UUID][]byte{
+		uuid.New(): []byte("0123456789abcdef"),
+		uuid.New(): []byte("fedcba9876543210"),
+	}
+	peerID := uuid.New()
+	idBin, err := peerID.MarshalBinary()
+	require.NoError(t, err)
+	peers := make(chan *PeerIdentity, 1)
+	cfg := Config{
+		ExternalAddresses: []string{"127.0.0.1:0"},
+		DTLS: DTLSConfig{
+			ExternalAddresses: []string{"127.0.0.1:0"},
+			PSK: func(deviceID uuid.UUID, identity []byte) ([]byte, error) {
+				psk, ok := psks[deviceID]
+				if !ok || !bytes.Equal(identity, idBin) {
+					return nil, errors.New("unknown pre-shared key")
+				}
+				return psk, nil
+			},
+		},
+	}
+	n, err := New(cfg, func(req *Request) (*pool.Message, error) {
+		peers <- req.Peer
+		resp := pool.NewMessage(req.Context())
+		resp.SetCode(codes.Content)
+		return resp, nil
+	}, log.NewNilLogger())
+	require.NoError(t, err)
+	go func() {
+		_ = n.Serve()
+	}()
+	defer func() {
+		errC := n.Close()
+		require.NoError(t, errC)
+	}()
+	for deviceID := range psks {
+		err = n.ListenDevice(deviceID)
+		require.NoError(t, err)
+	}
+
+	get := func(port string, psk []byte) (*PeerIdentity, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		conn, err := coapDtls.Dial("127.0.0.1:"+port, &dtls.Config{
+			PSK: func([]byte) ([]byte, error) {
+				return psk, nil
+			},
+			PSKIdentityHint: idBin,
+			CipherSuites:    []dtls.CipherSuiteID{dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256},
+		}, options.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		resp, err := conn.Get(ctx, "/test")
+		if err != nil {
+			return nil, err
+		}
+		require.Equal(t, codes.Content, resp.Code())
+		return <-peers, nil
+	}
+
+	// the listener of the device selects the pre-shared key
+	var otherPSK []byte
+	for deviceID, psk := range psks {
+		peer, errG := get(n.devices[deviceID].dtlsExternalAddressesPort[0].port, psk)
+		require.NoError(t, errG)
+		require.NotNil(t, peer)
+		require.Equal(t, idBin, peer.PSKIdentity)
+		require.Equal(t, peerID, peer.DeviceID())
+		if otherPSK != nil {
+			_, errG = get(n.devices[deviceID].dtlsExternalAddressesPort[0].port, otherPSK)
+			require.Error(t, errG)
+		}
+		otherPSK = psk
+	}
+	// the shared listener doesn't select any device
+	_, err = get(n.cfg.DTLS.externalAddressesPort[0].port, otherPSK)
+	require.Error(t, err)
+}
+
+func TestDTLSJustWorks(t *testing.T) {
+	peers := make(chan *PeerIdentity, 1)
+	ownedDeviceID := uuid.New()
+	cfg := Config{
+		ExternalAddresses: []string{"127.0.0.1:0"},
+		DTLS: DTLSConfig{
+			ExternalAddresses: []string{"127.0.0.1:0"},
+			JustWorks:         true,
+			AllowJustWorks: func(deviceID uuid.UUID) bool {
+				return deviceID != uuid.Nil && deviceID != ownedDeviceID
+			},
+		},
+	}
+	n, err := New(cfg, func(req *Request) (*pool.Message, error) {
+		peers <- req.Peer
+		resp := pool.NewMessage(req.Context())
+		resp.SetCode(codes.Content)
+		return resp, nil
+	}, log.NewNilLogger())
+	require.NoError(t, err)
+	go func() {
+		_ = n.Serve()
+	}()
+	defer func() {
+		errC := n.Close()
+		require.NoError(t, errC)
+	}()
+	deviceID := uuid.New()
+	err = n.ListenDevice(deviceID)
+	require.NoError(t, err)
+	err = n.ListenDevice(ownedDeviceID)
+	require.NoError(t, err)
+
+	get := func(port string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		conn, err := coapDtls.Dial("127.0.0.1:"+port, &dtls.Config{
+			CustomCipherSuites: func() []dtls.CipherSuite {
+				return []dtls.CipherSuite{cipher.NewTLSAecdhAes128Sha256(justWorksCipherSuiteID)}
+			},
+			CipherSuites: []dtls.CipherSuiteID{},
+		}, options.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		resp, err := conn.Get(ctx, "/test")
+		if err != nil {
+			return err
+		}
+		require.Equal(t, codes.Content, resp.Code())
+		return nil
+	}
+
+	err = get(n.devices[deviceID].dtlsExternalAddressesPort[0].port)
+	require.NoError(t, err)
+	peer := <-peers
+	require.NotNil(t, peer)
+	require.Empty(t, peer.Certificates)
+	require.Empty(t, peer.PSKIdentity)
+
+	// the cipher suite is not offered by the listeners of the device which doesn't allow it
+	err = get(n.devices[ownedDeviceID].dtlsExternalAddressesPort[0].port)
+	require.Error(t, err)
+	err = get(n.cfg.DTLS.externalAddressesPort[0].port)
+	require.Error(t, err)
+}
+
+func TestGetEndpointsWithTCP(t *testing.T) {
+	cfg := Config{
+		ExternalAddresses: []string{"127.0.0.1:42", "[::1]:42"},
+		TCP: TCPConfig{
+			ExternalAddresses: []string{"127.0.0.1:44", "[::1]:44"},
+		},
+		TLS: TLSConfig{
+			ExternalAddresses: []string{"127.0.0.1:45", "[::1]:45"},
+			Certificate:       &tls.Certificate{},
+		},
+	}
+	err := cfg.Validate()
+	require.NoError(t, err)
+	n := &Net{
+		cfg:    cfg,
+		logger: log.NewNilLogger(),
+	}
+	require.Equal(t, schema.Endpoints{
+		{URI: "coap://127.0.0.1:42"},
+		{URI: "coap+tcp://127.0.0.1:44"},
+		{URI: "coaps+tcp://127.0.0.1:45"},
+	}, n.GetEndpoints(nil, "127.0.0.1:44"))
+	require.Equal(t, schema.Endpoints{
+		{URI: "coap://[::1]:42"},
+		{URI: "coap+tcp://[::1]:44"},
+		{URI: "coaps+tcp://[::1]:45"},
+	}, n.GetEndpoints(nil, "[::1]:45"))
+}
+
+func TestTCP(t *testing.T) {
+	endpoints := make(chan schema.Endpoints, 2)
+	cfg := Config{
+		ExternalAddresses: []string{"127.0.0.1:0"},
+		TCP: TCPConfig{
+			ExternalAddresses: []string{"127.0.0.1:0"},
+		},
+	}
+	n, err := New(cfg, func(req *Request) (*pool.Message, error) {
+		require.Nil(t, req.Peer)
+		endpoints <- req.Endpoints
+		resp := pool.NewMessage(req.Context())
+		resp.SetCode(codes.Content)
+		return resp, nil
+	}, log.NewNilLogger())
+	require.NoError(t, err)
+	require.NotEqual(t, "0", n.cfg.TCP.externalAddressesPort[0].port)
+	go func() {
+		_ = n.Serve()
+	}()
+	defer func() {
+		errC := n.Close()
+		require.NoError(t, errC)
+	}()
+
+	tcpAddr := "127.0.0.1:" + n.cfg.TCP.externalAddressesPort[0].port
+	conn, err := tcp.Dial(tcpAddr)
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	// send more requests to check that the requests over TCP are not deduplicated
+	for i := 0; i < 2; i++ {
+		resp, err := conn.Get(ctx, "/test")
+		require.NoError(t, err)
+		require.Equal(t, codes.Content, resp.Code())
+		require.Contains(t, <-endpoints, schema.Endpoint{URI: "coap+tcp://" + tcpAddr})
+	}
+}
+
+func newTestTLSCertificate(t *testing.T) (*tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "uuid:" + uuid.NewString()},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        cert,
+	}, pool
+}
+
+func TestTLSPeerIdentity(t *testing.T) {
+	cert, caPool := newTestTLSCertificate(t)
+	peers := make(chan *PeerIdentity, 1)
+	cfg := Config{
+		ExternalAddresses: []string{"127.0.0.1:0"},
+		TLS: TLSConfig{
+			ExternalAddresses: []string{"127.0.0.1:0"},
+			Certificate:       cert,
+			CAPool:            caPool,
+		},
+	}
+	n, err := New(cfg, func(req *Request) (*pool.Message, error) {
+		peers <- req.Peer
+		resp := pool.NewMessage(req.Context())
+		resp.SetCode(codes.Content)
+		return resp, nil
+	}, log.NewNilLogger())
+	require.NoError(t, err)
+	go func() {
+		_ = n.Serve()
+	}()
+	defer func() {
+		errC := n.Close()
+		require.NoError(t, errC)
+	}()
+
+	conn, err := tcp.Dial("127.0.0.1:"+n.cfg.TLS.externalAddressesPort[0].port, options.WithTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*cert},
+		RootCAs:      caPool,
+	}))
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	resp, err := conn.Get(ctx, "/test")
+	require.NoError(t, err)
+	require.Equal(t, codes.Content, resp.Code())
+
+	peer := <-peers
+	require.NotNil(t, peer)
+	require.Len(t, peer.Certificates, 1)
+	require.Equal(t, cert.Leaf.Raw, peer.Certificates[0].Raw)
+}
@@ -0,0 +1,113 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package net
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	gonet "net"
+
+	"github.com/google/uuid"
+	"github.com/pion/dtls/v3"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+)
+
+// PeerIdentity is the authenticated identity of the peer of the secure connection.
+type PeerIdentity struct {
+	// Certificates of the peer, the first one is the leaf certificate.
+	Certificates []*x509.Certificate
+	// PSKIdentity is the identity of the peer used with the pre-shared key.
+	PSKIdentity []byte
+}
+
+// DeviceID returns the device ID from the identity certificate or from the PSK identity of the peer.
+func (p *PeerIdentity) DeviceID() uuid.UUID {
+	if p == nil {
+		return uuid.Nil
+	}
+	if len(p.Certificates) > 0 {
+		id, err := coap.GetDeviceIDFromIdentityCertificate(p.Certificates[0])
+		if err != nil {
+			return uuid.Nil
+		}
+		di, err := uuid.Parse(id)
+		if err != nil {
+			return uuid.Nil
+		}
+		return di
+	}
+	di, err := uuid.FromBytes(p.PSKIdentity)
+	if err != nil {
+		return uuid.Nil
+	}
+	return di
+}
+
+// PreSharedKeySubject returns the subject of the credential with the pre-shared key for the PSK identity,
+// the identity of 16 bytes is the UUID of the peer.
+func PreSharedKeySubject(identity []byte) string {
+	if id, err := uuid.FromBytes(identity); err == nil {
+		return id.String()
+	}
+	return string(identity)
+}
+
+type dtlsConnectionState interface {
+	ConnectionState() (dtls.State, bool)
+}
+
+type tlsConnectionState interface {
+	ConnectionState() tls.ConnectionState
+}
+
+// getPeerIdentity returns the identity of the peer for the secure connection, for the unsecure connection it returns nil.
+func getPeerIdentity(conn gonet.Conn) *PeerIdentity {
+	switch c := conn.(type) {
+	case dtlsConnectionState:
+		return getDTLSPeerIdentity(c)
+	case tlsConnectionState:
+		state := c.ConnectionState()
+		if !state.HandshakeComplete {
+			return nil
+		}
+		return &PeerIdentity{
+			Certificates: state.PeerCertificates,
+		}
+	}
+	return nil
+}
+
+func getDTLSPeerIdentity(c dtlsConnectionState) *PeerIdentity {
+	state, ok := c.ConnectionState()
+	if !ok {
+		return nil
+	}
+	certs := make([]*x509.Certificate, 0, len(state.PeerCertificates))
+	for _, raw := range state.PeerCertificates {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil
+		}
+		certs = append(certs, cert)
+	}
+	return &PeerIdentity{
+		Certificates: certs,
+		PSKIdentity:  state.IdentityHint,
+	}
+}
@@ -32,6 +32,10 @@ type Request struct {
 	*pool.Message
 	Conn      mux.Conn
 	Endpoints schema.Endpoints
+	// Peer is set for the requests received over the secure connection.
+	Peer *PeerIdentity
+	// ListenerDeviceID is set for the requests received on the listeners of the device created by Net.ListenDevice.
+	ListenerDeviceID uuid.UUID
 }
 
 type RequestHandler func(req *Request) (*pool.Message, error)
@@ -68,7 +72,11 @@ func (r *Request) Interface() string {
 	return v
 }
 
+// DeviceID returns the device of the listener which received the request, for the shared listeners it returns the di query.
 func (r *Request) DeviceID() uuid.UUID {
+	if r.ListenerDeviceID != uuid.Nil {
+		return r.ListenerDeviceID
+	}
 	v, err := r.GetValueFromQuery("di")
 	if err != nil {
 		return uuid.Nil
@@ -0,0 +1,98 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package resources
+
+import (
+	"context"
+	"io"
+
+	"github.com/plgd-dev/device/v2/bridge/net"
+	plgdResources "github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+)
+
+// BatchItem is the resource whose representation is included in the response of the batch interface.
+type BatchItem interface {
+	GetHref() string
+	GetResourceTypes() []string
+	ETag() []byte
+	SupportsOperations() SupportedOperation
+	HandleRequest(req *net.Request) (*pool.Message, error)
+}
+
+type batchAccessCheckKey struct{}
+
+// BatchAccessCheck returns true when the peer is allowed to read the resource href.
+type BatchAccessCheck func(peer *net.PeerIdentity, href string) bool
+
+// WithBatchAccessCheck sets the check of the access to the resources read by the batch interface, the resources
+// are not invoked directly so they are not checked by the access control of the request.
+func WithBatchAccessCheck(ctx context.Context, hasAccess BatchAccessCheck) context.Context {
+	return context.WithValue(ctx, batchAccessCheckKey{}, hasAccess)
+}
+
+// HasBatchAccess returns true when the resource href can be included in the response of the batch request.
+func HasBatchAccess(request *net.Request, href string) bool {
+	hasAccess, ok := request.Context().Value(batchAccessCheckKey{}).(BatchAccessCheck)
+	return !ok || hasAccess(request.Peer, href)
+}
+
+func newGetRequest(request *net.Request, href string) (*net.Request, error) {
+	msg := pool.NewMessage(request.Context())
+	msg.SetCode(codes.GET)
+	if err := msg.SetPath(href); err != nil {
+		return nil, err
+	}
+	return &net.Request{
+		Message:   msg,
+		Conn:      request.Conn,
+		Endpoints: request.Endpoints,
+		Peer:      request.Peer,
+	}, nil
+}
+
+// GetBatchRepresentation invokes the GET handler of the item and returns its CBOR representation with the href used in the batch response.
+func GetBatchRepresentation(request *net.Request, item BatchItem, href string) (plgdResources.BatchRepresentation, bool) {
+	if !item.SupportsOperations().HasOperation(SupportedOperationRead) || !HasBatchAccess(request, item.GetHref()) {
+		return plgdResources.BatchRepresentation{}, false
+	}
+	req, err := newGetRequest(request, item.GetHref())
+	if err != nil {
+		return plgdResources.BatchRepresentation{}, false
+	}
+	resp, err := item.HandleRequest(req)
+	if err != nil || resp == nil || resp.Code() != codes.Content || resp.Body() == nil {
+		return plgdResources.BatchRepresentation{}, false
+	}
+	if cf, err := resp.ContentFormat(); err != nil || (cf != message.AppCBOR && cf != message.AppOcfCbor) {
+		return plgdResources.BatchRepresentation{}, false
+	}
+	content, err := io.ReadAll(resp.Body())
+	if err != nil {
+		return plgdResources.BatchRepresentation{}, false
+	}
+	return plgdResources.BatchRepresentation{
+		HrefRaw:       href,
+		ETag:          item.ETag(),
+		Content:       content,
+		ResourceTypes: item.GetResourceTypes(),
+	}, true
+}
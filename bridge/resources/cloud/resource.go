@@ -19,15 +19,21 @@
 package cloud
 
 import (
+	"sync/atomic"
+
 	"github.com/plgd-dev/device/v2/bridge/net"
 	"github.com/plgd-dev/device/v2/bridge/resources"
 	plgdCloud "github.com/plgd-dev/device/v2/schema/cloud"
 	"github.com/plgd-dev/device/v2/schema/interfaces"
 	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/plgd-dev/go-coap/v3/pkg/sync"
 )
 
 type Resource struct {
 	*resources.Resource
+	manager          Manager
+	subscriptions    *sync.Map[uint64, func()]
+	lastSubscription atomic.Uint64
 }
 
 type Manager interface {
@@ -36,9 +42,31 @@ type Manager interface {
 }
 
 func New(uri string, m Manager) *Resource {
-	d := &Resource{}
+	d := &Resource{
+		manager:       m,
+		subscriptions: sync.NewMap[uint64, func()](),
+	}
 	d.Resource = resources.NewResource(uri, m.Get, m.Post, []string{plgdCloud.ResourceType}, []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_RW})
 	// don't publish cloud resource to cloud
 	d.PolicyBitMask &= ^resources.PublishToCloud
 	return d
 }
+
+func (d *Resource) CreateSubscription(req *net.Request, handler func(*pool.Message, error)) (func(), error) {
+	id := d.lastSubscription.Add(1)
+	d.subscriptions.Store(id, func() {
+		handler(d.manager.Get(req))
+	})
+	return func() {
+		d.subscriptions.Delete(id)
+	}, nil
+}
+
+// NotifyObservers sends the actual cloud configuration with the provisioning status and the last error code to the observers.
+func (d *Resource) NotifyObservers() {
+	d.UpdateETag()
+	d.subscriptions.Range(func(_ uint64, notify func()) bool {
+		notify()
+		return true
+	})
+}
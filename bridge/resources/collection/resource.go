@@ -0,0 +1,181 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/plgd-dev/device/v2/bridge/net"
+	"github.com/plgd-dev/device/v2/bridge/resources"
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	"github.com/plgd-dev/device/v2/schema"
+	plgdCollection "github.com/plgd-dev/device/v2/schema/collection"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	plgdResources "github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"go.uber.org/atomic"
+)
+
+// CreateChild creates the child resource with the href from the oic.if.create request. The handlers of the child
+// are provided by the integrator, the DELETE handler is set by the collection.
+type CreateChild func(href string, request plgdCollection.CreateRequest) (*resources.Resource, error)
+
+// AddChild is called when the child resource is created, typically it adds the child to the device.
+type AddChild func(child *resources.Resource)
+
+// DeleteChild is called when the child resource is deleted, typically it closes and deletes the child from the device.
+type DeleteChild func(href string) bool
+
+type Resource struct {
+	*resources.Resource
+	createChild CreateChild
+	addChild    AddChild
+	deleteChild DeleteChild
+	children    *sync.Map[string, *resources.Resource]
+	lastID      atomic.Uint64
+}
+
+func New(uri string, resourceTypes []string, createChild CreateChild, addChild AddChild, deleteChild DeleteChild) *Resource {
+	r := &Resource{
+		createChild: createChild,
+		addChild:    addChild,
+		deleteChild: deleteChild,
+		children:    sync.NewMap[string, *resources.Resource](),
+	}
+	r.Resource = resources.NewResource(uri,
+		r.Get,
+		r.Post,
+		append([]string{plgdCollection.ResourceType}, resourceTypes...),
+		[]string{interfaces.OC_IF_LL, interfaces.OC_IF_BASELINE, interfaces.OC_IF_B, interfaces.OC_IF_CREATE},
+		resources.WithPutHandler(r.Put),
+	)
+	return r
+}
+
+// GetChildren returns the child resources sorted by href.
+func (r *Resource) GetChildren() []*resources.Resource {
+	children := make([]*resources.Resource, 0, r.children.Length())
+	r.children.Range(func(_ string, child *resources.Resource) bool {
+		children = append(children, child)
+		return true
+	})
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].GetHref() < children[j].GetHref()
+	})
+	return children
+}
+
+func (r *Resource) getLinks(request *net.Request) schema.ResourceLinks {
+	children := r.GetChildren()
+	links := make(schema.ResourceLinks, 0, len(children))
+	for _, child := range children {
+		links = append(links, schema.ResourceLink{
+			Href:          child.GetHref(),
+			ResourceTypes: child.GetResourceTypes(),
+			Interfaces:    child.GetResourceInterfaces(),
+			Policy: &schema.Policy{
+				BitMask: child.GetPolicyBitMask() & (^resources.PublishToCloud),
+			},
+			Endpoints: request.Endpoints,
+		})
+	}
+	return links
+}
+
+func (r *Resource) getBatch(request *net.Request) plgdResources.BatchResourceDiscovery {
+	children := r.GetChildren()
+	batch := make(plgdResources.BatchResourceDiscovery, 0, len(children))
+	for _, child := range children {
+		if rep, ok := resources.GetBatchRepresentation(request, child, child.GetHref()); ok {
+			batch = append(batch, rep)
+		}
+	}
+	return batch
+}
+
+func (r *Resource) Get(request *net.Request) (*pool.Message, error) {
+	switch request.Interface() {
+	case interfaces.OC_IF_BASELINE:
+		return resources.CreateResponseContent(request.Context(), plgdCollection.Collection{
+			ResourceTypes: r.GetResourceTypes(),
+			Interfaces:    r.GetResourceInterfaces(),
+			Links:         r.getLinks(request),
+		}, codes.Content)
+	case interfaces.OC_IF_B:
+		return resources.CreateResponseContent(request.Context(), r.getBatch(request), codes.Content)
+	}
+	return resources.CreateResponseContent(request.Context(), r.getLinks(request), codes.Content)
+}
+
+// Post creates the child resource, only the oic.if.create interface is supported.
+func (r *Resource) Post(request *net.Request) (*pool.Message, error) {
+	if request.Interface() != interfaces.OC_IF_CREATE {
+		return resources.CreateResponseBadRequest(request.Context(), fmt.Errorf("unsupported interface('%v')", request.Interface()))
+	}
+	return r.create(request)
+}
+
+// Put creates the child resource.
+func (r *Resource) Put(request *net.Request) (*pool.Message, error) {
+	return r.create(request)
+}
+
+func (r *Resource) create(request *net.Request) (*pool.Message, error) {
+	var createReq plgdCollection.CreateRequest
+	if err := cbor.ReadFrom(request.Body(), &createReq); err != nil {
+		return resources.CreateResponseBadRequest(request.Context(), err)
+	}
+	if len(createReq.ResourceTypes) == 0 {
+		return resources.CreateResponseBadRequest(request.Context(), errors.New("resource types are required"))
+	}
+	href := r.GetHref() + "/" + strconv.FormatUint(r.lastID.Inc(), 10)
+	child, err := r.createChild(href, createReq)
+	if err != nil {
+		return resources.CreateResponseBadRequest(request.Context(), fmt.Errorf("cannot create resource %v: %w", href, err))
+	}
+	child.SetDeleteHandler(func(req *net.Request) (*pool.Message, error) {
+		return r.delete(req, child.GetHref())
+	})
+	r.children.Store(child.GetHref(), child)
+	r.addChild(child)
+	r.UpdateETag()
+	return resources.CreateResponseContent(request.Context(), plgdCollection.CreateResponse{
+		Href:          child.GetHref(),
+		ResourceTypes: child.GetResourceTypes(),
+		Interfaces:    child.GetResourceInterfaces(),
+		Policy: &schema.Policy{
+			BitMask: child.GetPolicyBitMask() & (^resources.PublishToCloud),
+		},
+		Representation: createReq.Representation,
+	}, codes.Created)
+}
+
+func (r *Resource) delete(request *net.Request, href string) (*pool.Message, error) {
+	if _, ok := r.children.LoadAndDelete(href); !ok {
+		return resources.CreateErrorResponse(request.Context(), codes.NotFound, fmt.Errorf("resource %v not found", href))
+	}
+	_ = r.deleteChild(href)
+	r.UpdateETag()
+	return resources.CreateResponseContent(request.Context(), "", codes.Deleted)
+}
@@ -0,0 +1,162 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package collection_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/plgd-dev/device/v2/bridge/net"
+	"github.com/plgd-dev/device/v2/bridge/resources"
+	"github.com/plgd-dev/device/v2/bridge/resources/collection"
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	"github.com/plgd-dev/device/v2/schema"
+	plgdCollection "github.com/plgd-dev/device/v2/schema/collection"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	plgdResources "github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/stretchr/testify/require"
+)
+
+type switchData struct {
+	Value bool `json:"value"`
+}
+
+func newRequest(t *testing.T, code codes.Code, iface string, body interface{}) *net.Request {
+	req := pool.NewMessage(context.Background())
+	req.SetCode(code)
+	if iface != "" {
+		req.AddQuery("if=" + iface)
+	}
+	if body != nil {
+		d, err := cbor.Encode(body)
+		require.NoError(t, err)
+		req.SetContentFormat(message.AppOcfCbor)
+		req.SetBody(bytes.NewReader(d))
+	}
+	return &net.Request{Message: req}
+}
+
+func newCollection(added map[string]*resources.Resource) *collection.Resource {
+	createChild := func(href string, req plgdCollection.CreateRequest) (*resources.Resource, error) {
+		return resources.NewResource(href, func(r *net.Request) (*pool.Message, error) {
+			return resources.CreateResponseContent(r.Context(), switchData{Value: true}, codes.Content)
+		}, nil, req.ResourceTypes, req.Interfaces), nil
+	}
+	addChild := func(child *resources.Resource) {
+		added[child.GetHref()] = child
+	}
+	deleteChild := func(href string) bool {
+		_, ok := added[href]
+		delete(added, href)
+		return ok
+	}
+	return collection.New("/switches", []string{"x.switches"}, createChild, addChild, deleteChild)
+}
+
+func TestCollectionCreateAndDelete(t *testing.T) {
+	added := make(map[string]*resources.Resource)
+	col := newCollection(added)
+	require.Equal(t, []string{plgdCollection.ResourceType, "x.switches"}, col.GetResourceTypes())
+	ops := col.SupportsOperations()
+	require.True(t, ops.HasOperation(resources.SupportedOperationCreate))
+
+	createReq := plgdCollection.CreateRequest{
+		ResourceTypes: []string{"oic.r.switch.binary"},
+		Interfaces:    []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_A},
+		Representation: map[string]interface{}{
+			"value": false,
+		},
+	}
+
+	// POST without the oic.if.create interface is rejected
+	resp, err := col.HandleRequest(newRequest(t, codes.POST, "", createReq))
+	require.NoError(t, err)
+	require.Equal(t, codes.BadRequest, resp.Code())
+
+	// missing resource types
+	resp, err = col.HandleRequest(newRequest(t, codes.PUT, interfaces.OC_IF_CREATE, plgdCollection.CreateRequest{}))
+	require.NoError(t, err)
+	require.Equal(t, codes.BadRequest, resp.Code())
+
+	etag := col.ETag()
+	resp, err = col.HandleRequest(newRequest(t, codes.POST, interfaces.OC_IF_CREATE, createReq))
+	require.NoError(t, err)
+	require.Equal(t, codes.Created, resp.Code())
+	var created plgdCollection.CreateResponse
+	err = cbor.ReadFrom(resp.Body(), &created)
+	require.NoError(t, err)
+	require.Equal(t, "/switches/1", created.Href)
+	require.Equal(t, createReq.ResourceTypes, created.ResourceTypes)
+	require.NotEqual(t, etag, col.ETag())
+
+	resp, err = col.HandleRequest(newRequest(t, codes.PUT, interfaces.OC_IF_CREATE, createReq))
+	require.NoError(t, err)
+	require.Equal(t, codes.Created, resp.Code())
+	require.Len(t, added, 2)
+	require.Len(t, col.GetChildren(), 2)
+
+	// links list
+	resp, err = col.HandleRequest(newRequest(t, codes.GET, "", nil))
+	require.NoError(t, err)
+	require.Equal(t, codes.Content, resp.Code())
+	var links schema.ResourceLinks
+	err = cbor.ReadFrom(resp.Body(), &links)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	require.Equal(t, "/switches/1", links[0].Href)
+	require.Equal(t, "/switches/2", links[1].Href)
+
+	// baseline
+	resp, err = col.HandleRequest(newRequest(t, codes.GET, interfaces.OC_IF_BASELINE, nil))
+	require.NoError(t, err)
+	var baseline plgdCollection.Collection
+	err = cbor.ReadFrom(resp.Body(), &baseline)
+	require.NoError(t, err)
+	require.Equal(t, col.GetResourceTypes(), baseline.ResourceTypes)
+	require.Len(t, baseline.Links, 2)
+
+	// batch
+	resp, err = col.HandleRequest(newRequest(t, codes.GET, interfaces.OC_IF_B, nil))
+	require.NoError(t, err)
+	var batch plgdResources.BatchResourceDiscovery
+	err = cbor.ReadFrom(resp.Body(), &batch)
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+	var data switchData
+	err = cbor.Decode(batch[0].Content, &data)
+	require.NoError(t, err)
+	require.True(t, data.Value)
+
+	// delete child
+	child := added["/switches/1"]
+	require.True(t, child.SupportsOperations().HasOperation(resources.SupportedOperationDelete))
+	resp, err = child.HandleRequest(newRequest(t, codes.DELETE, "", nil))
+	require.NoError(t, err)
+	require.Equal(t, codes.Deleted, resp.Code())
+	require.Len(t, added, 1)
+	require.Len(t, col.GetChildren(), 1)
+
+	resp, err = child.HandleRequest(newRequest(t, codes.DELETE, "", nil))
+	require.NoError(t, err)
+	require.Equal(t, codes.NotFound, resp.Code())
+}
@@ -19,8 +19,12 @@
 package discovery
 
 import (
+	"bytes"
+	"sort"
+
 	"github.com/plgd-dev/device/v2/bridge/net"
 	"github.com/plgd-dev/device/v2/bridge/resources"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
 	"github.com/plgd-dev/device/v2/schema"
 	"github.com/plgd-dev/device/v2/schema/interfaces"
 	plgdResources "github.com/plgd-dev/device/v2/schema/resources"
@@ -30,16 +34,26 @@ import (
 
 type GetLinksHandler func(*net.Request) schema.ResourceLinks
 
+// GetBatchItemsHandler returns the resources included in the response of the batch interface.
+type GetBatchItemsHandler func(*net.Request) []resources.BatchItem
+
 type Resource struct {
 	*resources.Resource
-	getLinks GetLinksHandler
+	getLinks      GetLinksHandler
+	getBatchItems GetBatchItemsHandler
 }
 
-func New(uri string, getLinks GetLinksHandler) *Resource {
+// New creates the discovery resource, the batch interface is supported only when getBatchItems is set.
+func New(uri string, getLinks GetLinksHandler, getBatchItems GetBatchItemsHandler) *Resource {
 	d := &Resource{
-		getLinks: getLinks,
+		getLinks:      getLinks,
+		getBatchItems: getBatchItems,
+	}
+	resourceInterfaces := []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_R}
+	if getBatchItems != nil {
+		resourceInterfaces = append(resourceInterfaces, interfaces.OC_IF_B)
 	}
-	d.Resource = resources.NewResource(uri, d.Get, nil, []string{plgdResources.ResourceType}, []string{interfaces.OC_IF_BASELINE, interfaces.OC_IF_R})
+	d.Resource = resources.NewResource(uri, d.Get, nil, []string{plgdResources.ResourceType}, resourceInterfaces)
 	return d
 }
 
@@ -50,7 +64,77 @@ func PatchLinks(links schema.ResourceLinks, deviceID string) schema.ResourceLink
 	return links
 }
 
+func maxETag(items []resources.BatchItem) []byte {
+	var etag []byte
+	for _, item := range items {
+		if e := item.ETag(); bytes.Compare(e, etag) > 0 {
+			etag = e
+		}
+	}
+	return etag
+}
+
+// getIncrementalChangesETag returns the newest ETag from the incChanges query which is still held by one of the items.
+// The items with a newer ETag have changed since the client got the ETag.
+func getIncrementalChangesETag(etags [][]byte, items []resources.BatchItem) []byte {
+	var etag []byte
+	for _, item := range items {
+		e := item.ETag()
+		for _, qe := range etags {
+			if bytes.Equal(e, qe) && bytes.Compare(e, etag) > 0 {
+				etag = e
+			}
+		}
+	}
+	return etag
+}
+
+func (d *Resource) getBatch(request *net.Request) (*pool.Message, error) {
+	// the batch request without queries is valid
+	queries, _ := request.Queries()
+	etags, err := coap.DecodeETagsForIncrementalChanges(queries)
+	if err != nil {
+		return resources.CreateResponseBadRequest(request.Context(), err)
+	}
+	items := d.getBatchItems(request)
+	incChangesETag := getIncrementalChangesETag(etags, items)
+	deviceID := request.DeviceID().String()
+	batch := make(plgdResources.BatchResourceDiscovery, 0, len(items))
+	for _, item := range items {
+		if incChangesETag != nil && bytes.Compare(item.ETag(), incChangesETag) <= 0 {
+			continue
+		}
+		if rep, ok := resources.GetBatchRepresentation(request, item, "ocf://"+deviceID+item.GetHref()); ok {
+			batch = append(batch, rep)
+		}
+	}
+	etag := maxETag(items)
+	if incChangesETag != nil && len(batch) == 0 {
+		resp := pool.NewMessage(request.Context())
+		resp.SetCode(codes.Valid)
+		if etag != nil {
+			_ = resp.SetETag(etag)
+		}
+		return resp, nil
+	}
+	// the most recently changed resource is the first one
+	sort.SliceStable(batch, func(i, j int) bool {
+		return bytes.Compare(batch[i].ETag, batch[j].ETag) > 0
+	})
+	resp, err := resources.CreateResponseContent(request.Context(), batch, codes.Content)
+	if err != nil {
+		return nil, err
+	}
+	if etag != nil {
+		_ = resp.SetETag(etag)
+	}
+	return resp, nil
+}
+
 func (d *Resource) Get(request *net.Request) (*pool.Message, error) {
+	if request.Interface() == interfaces.OC_IF_B && d.getBatchItems != nil {
+		return d.getBatch(request)
+	}
 	links := PatchLinks(d.getLinks(request), request.DeviceID().String())
 	return resources.CreateResponseContent(request.Context(), links, codes.Content)
 }
@@ -0,0 +1,150 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package discovery_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/net"
+	"github.com/plgd-dev/device/v2/bridge/resources"
+	"github.com/plgd-dev/device/v2/bridge/resources/discovery"
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+	plgdResources "github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	"github.com/stretchr/testify/require"
+)
+
+type testData struct {
+	Href string `json:"href"`
+}
+
+func newTestResource(href string) *resources.Resource {
+	return resources.NewResource(href, func(req *net.Request) (*pool.Message, error) {
+		return resources.CreateResponseContent(req.Context(), testData{Href: href}, codes.Content)
+	}, nil, []string{"x.test"}, []string{interfaces.OC_IF_BASELINE})
+}
+
+func newBatchRequest(t *testing.T, deviceID uuid.UUID, queries ...string) *net.Request {
+	msg := pool.NewMessage(context.Background())
+	msg.SetCode(codes.GET)
+	err := msg.SetPath(plgdResources.ResourceURI)
+	require.NoError(t, err)
+	msg.AddQuery("if=" + interfaces.OC_IF_B)
+	msg.AddQuery("di=" + deviceID.String())
+	for _, q := range queries {
+		msg.AddQuery(q)
+	}
+	return &net.Request{Message: msg}
+}
+
+func getBatch(t *testing.T, d *discovery.Resource, req *net.Request) (*pool.Message, plgdResources.BatchResourceDiscovery) {
+	resp, err := d.HandleRequest(req)
+	require.NoError(t, err)
+	var batch plgdResources.BatchResourceDiscovery
+	if resp.Code() == codes.Content {
+		err = cbor.ReadFrom(resp.Body(), &batch)
+		require.NoError(t, err)
+	}
+	return resp, batch
+}
+
+func TestBatch(t *testing.T) {
+	deviceID := uuid.New()
+	res1 := newTestResource("/test/1")
+	res2 := newTestResource("/test/2")
+	res3 := newTestResource("/test/3")
+	d := discovery.New(plgdResources.ResourceURI, func(*net.Request) schema.ResourceLinks {
+		return nil
+	}, func(*net.Request) []resources.BatchItem {
+		return []resources.BatchItem{res1, res2, res3}
+	})
+	defer d.Close()
+	require.Contains(t, d.GetResourceInterfaces(), interfaces.OC_IF_B)
+
+	resp, batch := getBatch(t, d, newBatchRequest(t, deviceID))
+	require.Equal(t, codes.Content, resp.Code())
+	require.Len(t, batch, 3)
+	etag, err := resp.ETag()
+	require.NoError(t, err)
+	// the most recently changed resource is the first one
+	require.Equal(t, res3.ETag(), etag)
+	require.Equal(t, res3.ETag(), batch[0].ETag)
+	require.Equal(t, "ocf://"+deviceID.String()+"/test/3", batch[0].HrefRaw)
+	require.Equal(t, "/test/3", batch[0].Href())
+	var data testData
+	err = cbor.Decode(batch[0].Content, &data)
+	require.NoError(t, err)
+	require.Equal(t, "/test/3", data.Href)
+
+	// nothing has changed
+	etags := [][]byte{res1.ETag(), res2.ETag(), res3.ETag()}
+	queries := coap.EncodeETagsForIncrementalChanges(etags)
+	resp, _ = getBatch(t, d, newBatchRequest(t, deviceID, queries...))
+	require.Equal(t, codes.Valid, resp.Code())
+	etag, err = resp.ETag()
+	require.NoError(t, err)
+	require.Equal(t, res3.ETag(), etag)
+
+	// only the changed resource is returned
+	res1.UpdateETag()
+	resp, batch = getBatch(t, d, newBatchRequest(t, deviceID, queries...))
+	require.Equal(t, codes.Content, resp.Code())
+	require.Len(t, batch, 1)
+	require.Equal(t, "/test/1", batch[0].Href())
+	etag, err = resp.ETag()
+	require.NoError(t, err)
+	require.Equal(t, res1.ETag(), etag)
+
+	// unknown etags are ignored
+	queries = coap.EncodeETagsForIncrementalChanges([][]byte{[]byte("unknown")})
+	_, batch = getBatch(t, d, newBatchRequest(t, deviceID, queries...))
+	require.Len(t, batch, 3)
+
+	// the client has the current batch
+	req := newBatchRequest(t, deviceID)
+	req.AddOptionBytes(message.ETag, res1.ETag())
+	resp, _ = getBatch(t, d, req)
+	require.Equal(t, codes.Valid, resp.Code())
+
+	resp, _ = getBatch(t, d, newBatchRequest(t, deviceID, "incChanges=!!"))
+	require.Equal(t, codes.BadRequest, resp.Code())
+}
+
+func TestBatchNotSupported(t *testing.T) {
+	d := discovery.New(plgdResources.ResourceURI, func(*net.Request) schema.ResourceLinks {
+		return schema.ResourceLinks{{Href: "/test"}}
+	}, nil)
+	defer d.Close()
+	require.NotContains(t, d.GetResourceInterfaces(), interfaces.OC_IF_B)
+
+	resp, err := d.HandleRequest(newBatchRequest(t, uuid.New()))
+	require.NoError(t, err)
+	require.Equal(t, codes.Content, resp.Code())
+	var links schema.ResourceLinks
+	err = cbor.ReadFrom(resp.Body(), &links)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+}
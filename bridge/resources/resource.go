@@ -44,6 +44,10 @@ type GetHandlerFunc func(*net.Request) (*pool.Message, error)
 
 type PostHandlerFunc func(*net.Request) (*pool.Message, error)
 
+type PutHandlerFunc func(*net.Request) (*pool.Message, error)
+
+type DeleteHandlerFunc func(*net.Request) (*pool.Message, error)
+
 type CreateSubscriptionFunc func(*net.Request, func(*pool.Message, error)) (func(), error)
 
 const PublishToCloud schema.BitMask = 1 << 7
@@ -59,6 +63,8 @@ type Resource struct {
 	PolicyBitMask       schema.BitMask
 	getHandler          GetHandlerFunc
 	postHandler         PostHandlerFunc
+	putHandler          PutHandlerFunc
+	deleteHandler       DeleteHandlerFunc
 	createSubscription  CreateSubscriptionFunc
 	closed              atomic.Bool
 	createdSubscription *sync.Map[string, *subscription]
@@ -81,6 +87,8 @@ const (
 	SupportedOperationRead SupportedOperation = 0x1 << iota
 	SupportedOperationWrite
 	SupportedOperationObserve
+	SupportedOperationCreate
+	SupportedOperationDelete
 )
 
 func (o SupportedOperation) HasOperation(operation SupportedOperation) bool {
@@ -98,6 +106,12 @@ func (r *Resource) SupportsOperations() SupportedOperation {
 	if r.PolicyBitMask&schema.Observable != 0 {
 		operations |= SupportedOperationObserve
 	}
+	if r.putHandler != nil {
+		operations |= SupportedOperationCreate
+	}
+	if r.deleteHandler != nil {
+		operations |= SupportedOperationDelete
+	}
 	return operations
 }
 
@@ -130,7 +144,24 @@ func Unique(strSlice []string) []string {
 	return list
 }
 
-func NewResource(href string, getHandler GetHandlerFunc, postHandler PostHandlerFunc, resourceTypes, resourceInterfaces []string) *Resource {
+// Option sets the optional handlers of the resource.
+type Option func(*Resource)
+
+// WithPutHandler sets the handler of the PUT requests, which are used to create the resource.
+func WithPutHandler(putHandler PutHandlerFunc) Option {
+	return func(r *Resource) {
+		r.putHandler = putHandler
+	}
+}
+
+// WithDeleteHandler sets the handler of the DELETE requests.
+func WithDeleteHandler(deleteHandler DeleteHandlerFunc) Option {
+	return func(r *Resource) {
+		r.deleteHandler = deleteHandler
+	}
+}
+
+func NewResource(href string, getHandler GetHandlerFunc, postHandler PostHandlerFunc, resourceTypes, resourceInterfaces []string, opts ...Option) *Resource {
 	r := &Resource{
 		Href:                href,
 		ResourceInterfaces:  Unique(resourceInterfaces),
@@ -139,11 +170,22 @@ func NewResource(href string, getHandler GetHandlerFunc, postHandler PostHandler
 		postHandler:         postHandler,
 		createdSubscription: sync.NewMap[string, *subscription](),
 	}
+	for _, o := range opts {
+		o(r)
+	}
 	r.SetResourceTypes(resourceTypes)
 	r.etag.Store(GetETag())
 	return r
 }
 
+func (r *Resource) SetPutHandler(putHandler PutHandlerFunc) {
+	r.putHandler = putHandler
+}
+
+func (r *Resource) SetDeleteHandler(deleteHandler DeleteHandlerFunc) {
+	r.deleteHandler = deleteHandler
+}
+
 func createTextPlainResponse(ctx context.Context, token message.Token, code codes.Code, body []byte) *pool.Message {
 	msg := pool.NewMessage(ctx)
 	msg.SetCode(code)
@@ -309,6 +351,28 @@ func (r *Resource) observerHandler(req *net.Request, createSubscription bool) (*
 	return resp, nil
 }
 
+// hasETag checks whether one of the ETag options of the request matches the etag.
+func hasETag(req *net.Request, etag []byte) bool {
+	for _, o := range req.Options() {
+		if o.ID == message.ETag && bytes.Equal(o.Value, etag) {
+			return true
+		}
+	}
+	return false
+}
+
+// setValidForMatchingETag changes the response to 2.03 Valid without payload when the client has the current
+// representation, see https://datatracker.ietf.org/doc/html/rfc7252#section-5.10.6
+func setValidForMatchingETag(req *net.Request, resp *pool.Message) {
+	etag, err := resp.ETag()
+	if err != nil || !hasETag(req, etag) {
+		return
+	}
+	resp.SetCode(codes.Valid)
+	resp.Remove(message.ContentFormat)
+	resp.SetBody(nil)
+}
+
 func (r *Resource) HandleRequest(req *net.Request) (*pool.Message, error) {
 	if req.Code() == codes.GET && r.getHandler != nil { //nolint:nestif
 		var resp *pool.Message
@@ -319,15 +383,24 @@ func (r *Resource) HandleRequest(req *net.Request) (*pool.Message, error) {
 			resp, err = r.getHandler(req)
 		}
 		if resp != nil && resp.Code() == codes.Content {
-			etag := r.ETag()
-			if etag != nil {
-				_ = resp.SetETag(etag)
+			if !resp.HasOption(message.ETag) {
+				etag := r.ETag()
+				if etag != nil {
+					_ = resp.SetETag(etag)
+				}
 			}
+			setValidForMatchingETag(req, resp)
 		}
 		return resp, err
 	}
 	if req.Code() == codes.POST && r.postHandler != nil {
 		return r.postHandler(req)
 	}
+	if req.Code() == codes.PUT && r.putHandler != nil {
+		return r.putHandler(req)
+	}
+	if req.Code() == codes.DELETE && r.deleteHandler != nil {
+		return r.deleteHandler(req)
+	}
 	return CreateResponseMethodNotAllowed(req.Context(), req.Token()), nil
 }
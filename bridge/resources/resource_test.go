@@ -1,13 +1,17 @@
 package resources_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/plgd-dev/device/v2/bridge/net"
 	"github.com/plgd-dev/device/v2/bridge/resources"
 	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
 	"github.com/plgd-dev/go-coap/v3/message/pool"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSupportedOperationHasOperation(t *testing.T) {
@@ -23,6 +27,7 @@ func TestSupportedOperationHasOperation(t *testing.T) {
 		{"Test Read and Write", resources.SupportedOperationRead | resources.SupportedOperationWrite, resources.SupportedOperationRead, true},
 		{"Test Write and Observe", resources.SupportedOperationWrite | resources.SupportedOperationObserve, resources.SupportedOperationObserve, true},
 		{"Test All", resources.SupportedOperationRead | resources.SupportedOperationWrite | resources.SupportedOperationObserve, resources.SupportedOperationRead, true},
+		{"Test Create and Delete", resources.SupportedOperationCreate | resources.SupportedOperationDelete, resources.SupportedOperationDelete, true},
 		{"Test None", 0, resources.SupportedOperationRead, false},
 	}
 
@@ -58,3 +63,91 @@ func TestResourceSupportsOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceSupportsCreateAndDeleteOperations(t *testing.T) {
+	handler := func(*net.Request) (*pool.Message, error) { return &pool.Message{}, nil }
+	r := resources.NewResource("/test", handler, nil, nil, nil, resources.WithPutHandler(handler), resources.WithDeleteHandler(handler))
+	assert.Equal(t, resources.SupportedOperationRead|resources.SupportedOperationCreate|resources.SupportedOperationDelete, r.SupportsOperations())
+
+	r.SetPutHandler(nil)
+	r.SetDeleteHandler(nil)
+	assert.Equal(t, resources.SupportedOperationRead, r.SupportsOperations())
+}
+
+func TestResourceHandleRequest(t *testing.T) {
+	newHandler := func(code codes.Code) func(*net.Request) (*pool.Message, error) {
+		return func(req *net.Request) (*pool.Message, error) {
+			resp := pool.NewMessage(req.Context())
+			resp.SetCode(code)
+			return resp, nil
+		}
+	}
+	r := resources.NewResource("/test", newHandler(codes.Content), newHandler(codes.Changed), nil, nil,
+		resources.WithPutHandler(newHandler(codes.Created)), resources.WithDeleteHandler(newHandler(codes.Deleted)))
+	readOnly := resources.NewResource("/test", newHandler(codes.Content), nil, nil, nil)
+
+	tests := []struct {
+		name     string
+		resource *resources.Resource
+		code     codes.Code
+		wantCode codes.Code
+	}{
+		{"GET", r, codes.GET, codes.Content},
+		{"POST", r, codes.POST, codes.Changed},
+		{"PUT", r, codes.PUT, codes.Created},
+		{"DELETE", r, codes.DELETE, codes.Deleted},
+		{"POST not allowed", readOnly, codes.POST, codes.MethodNotAllowed},
+		{"PUT not allowed", readOnly, codes.PUT, codes.MethodNotAllowed},
+		{"DELETE not allowed", readOnly, codes.DELETE, codes.MethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := pool.NewMessage(context.Background())
+			req.SetCode(tt.code)
+			resp, err := tt.resource.HandleRequest(&net.Request{Message: req})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCode, resp.Code())
+		})
+	}
+}
+
+func TestResourceConditionalGet(t *testing.T) {
+	r := resources.NewResource("/test", func(req *net.Request) (*pool.Message, error) {
+		return resources.CreateResponseContent(req.Context(), map[string]interface{}{"value": true}, codes.Content)
+	}, nil, nil, nil)
+	get := func(etags ...[]byte) *pool.Message {
+		req := pool.NewMessage(context.Background())
+		req.SetCode(codes.GET)
+		for _, etag := range etags {
+			req.AddOptionBytes(message.ETag, etag)
+		}
+		resp, err := r.HandleRequest(&net.Request{Message: req})
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := get()
+	require.Equal(t, codes.Content, resp.Code())
+	require.NotNil(t, resp.Body())
+	etag, err := resp.ETag()
+	require.NoError(t, err)
+	require.Equal(t, r.ETag(), etag)
+
+	// the client has the current representation
+	resp = get([]byte("invalid"), etag)
+	require.Equal(t, codes.Valid, resp.Code())
+	require.Nil(t, resp.Body())
+	require.False(t, resp.HasOption(message.ContentFormat))
+	validETag, err := resp.ETag()
+	require.NoError(t, err)
+	require.Equal(t, etag, validETag)
+
+	// the representation has changed
+	r.UpdateETag()
+	resp = get(etag)
+	require.Equal(t, codes.Content, resp.Code())
+	require.NotNil(t, resp.Body())
+	newETag, err := resp.ETag()
+	require.NoError(t, err)
+	require.NotEqual(t, etag, newETag)
+}
@@ -21,6 +21,7 @@ package service
 import (
 	"errors"
 	"fmt"
+	gonet "net"
 
 	"github.com/plgd-dev/device/v2/bridge/net"
 )
@@ -40,14 +41,52 @@ func (c *CoAPConfig) Validate() error {
 	return nil
 }
 
+// HTTPConfig configures the local management API. The API is not authenticated, so it listens only on the loopback
+// interface unless AllowRemoteAccess is set.
+type HTTPConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Address string `yaml:"address"`
+	// AllowRemoteAccess allows the address which is not on the loopback interface, anyone who can reach it manages the devices.
+	AllowRemoteAccess bool `yaml:"allowRemoteAccess"`
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := gonet.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (c *HTTPConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Address == "" {
+		return errors.New("address is required")
+	}
+	host, _, err := gonet.SplitHostPort(c.Address)
+	if err != nil {
+		return fmt.Errorf("address('%v') is invalid: %w", c.Address, err)
+	}
+	if !c.AllowRemoteAccess && !isLoopbackHost(host) {
+		return fmt.Errorf("address('%v') is not on the loopback interface, set allowRemoteAccess to listen on it", c.Address)
+	}
+	return nil
+}
+
 type APIConfig struct {
 	CoAP CoAPConfig `yaml:"coap"`
+	HTTP HTTPConfig `yaml:"http"`
 }
 
 func (c *APIConfig) Validate() error {
 	if err := c.CoAP.Validate(); err != nil {
 		return fmt.Errorf("coap.%w", err)
 	}
+	if err := c.HTTP.Validate(); err != nil {
+		return fmt.Errorf("http.%w", err)
+	}
 	return nil
 }
 
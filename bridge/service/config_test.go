@@ -75,6 +75,51 @@ func TestAPIConfigValidate(t *testing.T) {
 			apiConfig: service.APIConfig{CoAP: service.CoAPConfig{Config: net.Config{ExternalAddresses: []string{"localhost:12345"}}}},
 			wantErr:   true,
 		},
+		{
+			name: "ValidHTTPConfig",
+			apiConfig: service.APIConfig{
+				CoAP: service.CoAPConfig{ID: "test", Config: net.Config{ExternalAddresses: []string{"localhost:12345"}}},
+				HTTP: service.HTTPConfig{Enabled: true, Address: "127.0.0.1:8080"},
+			},
+		},
+		{
+			name: "HTTPOnLocalhost",
+			apiConfig: service.APIConfig{
+				CoAP: service.CoAPConfig{ID: "test", Config: net.Config{ExternalAddresses: []string{"localhost:12345"}}},
+				HTTP: service.HTTPConfig{Enabled: true, Address: "localhost:8080"},
+			},
+		},
+		{
+			name: "HTTPOnAllInterfaces",
+			apiConfig: service.APIConfig{
+				CoAP: service.CoAPConfig{ID: "test", Config: net.Config{ExternalAddresses: []string{"localhost:12345"}}},
+				HTTP: service.HTTPConfig{Enabled: true, Address: ":8080"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HTTPRemoteAccess",
+			apiConfig: service.APIConfig{
+				CoAP: service.CoAPConfig{ID: "test", Config: net.Config{ExternalAddresses: []string{"localhost:12345"}}},
+				HTTP: service.HTTPConfig{Enabled: true, Address: "0.0.0.0:8080", AllowRemoteAccess: true},
+			},
+		},
+		{
+			name: "HTTPInvalidAddress",
+			apiConfig: service.APIConfig{
+				CoAP: service.CoAPConfig{ID: "test", Config: net.Config{ExternalAddresses: []string{"localhost:12345"}}},
+				HTTP: service.HTTPConfig{Enabled: true, Address: "127.0.0.1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HTTPWithoutAddress",
+			apiConfig: service.APIConfig{
+				CoAP: service.CoAPConfig{ID: "test", Config: net.Config{ExternalAddresses: []string{"localhost:12345"}}},
+				HTTP: service.HTTPConfig{Enabled: true},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,133 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/device"
+	"github.com/plgd-dev/device/v2/pkg/atomicfile"
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+)
+
+const fileStoreExtension = ".cbor"
+
+// FileStore stores the configuration of each device in a separate file of the directory. The configuration is encoded
+// in CBOR to keep the byte strings of the credentials, and the file is replaced atomically, so a crash during the write
+// never leaves a partially written configuration.
+type FileStore struct {
+	dir  string
+	lock sync.Mutex
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, errors.New("directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create directory %v: %w", dir, err)
+	}
+	return &FileStore{
+		dir: dir,
+	}, nil
+}
+
+func (s *FileStore) getPath(id uuid.UUID) string {
+	return filepath.Join(s.dir, id.String()+fileStoreExtension)
+}
+
+func (s *FileStore) loadFile(path string) (device.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return device.Config{}, err
+	}
+	var cfg device.Config
+	if err = cbor.Decode(data, &cfg); err != nil {
+		return device.Config{}, err
+	}
+	return cfg, nil
+}
+
+func (s *FileStore) Load() ([]device.Config, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read directory %v: %w", s.dir, err)
+	}
+	cfgs := make([]device.Config, 0, len(entries))
+	var errs []error
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, fileStoreExtension) {
+			continue
+		}
+		id, err := uuid.Parse(strings.TrimSuffix(name, fileStoreExtension))
+		if err != nil {
+			continue
+		}
+		cfg, err := s.loadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot load device %v: %w", id, err))
+			continue
+		}
+		if cfg.ID != id {
+			errs = append(errs, fmt.Errorf("cannot load device %v: file contains device %v", id, cfg.ID))
+			continue
+		}
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs, errors.Join(errs...)
+}
+
+func (s *FileStore) Save(cfg device.Config) error {
+	if cfg.ID == uuid.Nil {
+		return errors.New("invalid device id")
+	}
+	data, err := cbor.Encode(cfg)
+	if err != nil {
+		return fmt.Errorf("cannot encode device %v: %w", cfg.ID, err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err = atomicfile.Write(s.getPath(cfg.ID), data); err != nil {
+		return fmt.Errorf("cannot store device %v: %w", cfg.ID, err)
+	}
+	return nil
+}
+
+func (s *FileStore) Delete(id uuid.UUID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	err := os.Remove(s.getPath(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrDeviceNotStored
+	}
+	if err != nil {
+		return fmt.Errorf("cannot delete device %v: %w", id, err)
+	}
+	return nil
+}
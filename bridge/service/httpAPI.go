@@ -0,0 +1,293 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	gonet "net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/device"
+	"github.com/plgd-dev/device/v2/pkg/log"
+	"github.com/plgd-dev/device/v2/schema/cloud"
+)
+
+const (
+	HTTPDevicesPath         = "/api/v1/devices"
+	HTTPDevicePath          = HTTPDevicesPath + "/{id}"
+	HTTPDeviceResourcesPath = HTTPDevicePath + "/resources"
+	HTTPCloudReconnectPath  = HTTPDevicePath + "/cloud/reconnect"
+	HTTPCloudUnregisterPath = HTTPDevicePath + "/cloud/unregister"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	// maxDeviceSpecSize limits the body of the request which creates the device.
+	maxDeviceSpecSize = 64 * 1024
+)
+
+// DeviceSpec describes the device created by the management API, when the ID is not set a new one is generated.
+type DeviceSpec struct {
+	ID            uuid.UUID `json:"id,omitempty"`
+	Name          string    `json:"name"`
+	ResourceTypes []string  `json:"resourceTypes,omitempty"`
+}
+
+// NewDeviceFromSpecFunc creates the device with its resources, the device is initialized by the service.
+type NewDeviceFromSpecFunc func(spec DeviceSpec, piid uuid.UUID) (Device, error)
+
+type CloudStatus struct {
+	Enabled            bool                     `json:"enabled"`
+	CloudID            string                   `json:"sid,omitempty"`
+	URL                string                   `json:"cis,omitempty"`
+	ProvisioningStatus cloud.ProvisioningStatus `json:"cps,omitempty"`
+	LastErrorCode      int                      `json:"clec"`
+}
+
+type DeviceInfo struct {
+	ID                    uuid.UUID   `json:"id"`
+	Name                  string      `json:"name"`
+	ProtocolIndependentID uuid.UUID   `json:"piid"`
+	ResourceTypes         []string    `json:"resourceTypes"`
+	Cloud                 CloudStatus `json:"cloud"`
+}
+
+type ResourceInfo struct {
+	Href          string   `json:"href"`
+	ResourceTypes []string `json:"rt"`
+	Interfaces    []string `json:"if"`
+}
+
+type httpError struct {
+	Error string `json:"error"`
+}
+
+type httpAPI struct {
+	s                 *Service
+	newDeviceFromSpec NewDeviceFromSpecFunc
+	server            *http.Server
+	listener          gonet.Listener
+	logger            log.Logger
+}
+
+func newHTTPAPI(cfg HTTPConfig, s *Service, newDeviceFromSpec NewDeviceFromSpecFunc, logger log.Logger) (*httpAPI, error) {
+	l, err := gonet.Listen("tcp", cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on %v: %w", cfg.Address, err)
+	}
+	h := &httpAPI{
+		s:                 s,
+		newDeviceFromSpec: newDeviceFromSpec,
+		listener:          l,
+		logger:            logger,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+HTTPDevicesPath, h.listDevices)
+	mux.HandleFunc("POST "+HTTPDevicesPath, h.createDevice)
+	mux.HandleFunc("GET "+HTTPDevicePath, h.getDevice)
+	mux.HandleFunc("DELETE "+HTTPDevicePath, h.deleteDevice)
+	mux.HandleFunc("GET "+HTTPDeviceResourcesPath, h.listResources)
+	mux.HandleFunc("POST "+HTTPCloudReconnectPath, h.reconnectCloud)
+	mux.HandleFunc("POST "+HTTPCloudUnregisterPath, h.unregisterCloud)
+	h.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	return h, nil
+}
+
+func (h *httpAPI) getAddress() string {
+	return h.listener.Addr().String()
+}
+
+func (h *httpAPI) serve() error {
+	err := h.server.Serve(h.listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (h *httpAPI) close() error {
+	return h.server.Close()
+}
+
+func (h *httpAPI) writeResponse(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if v == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Errorf("cannot write response: %w", err)
+	}
+}
+
+func (h *httpAPI) writeError(w http.ResponseWriter, code int, err error) {
+	h.writeResponse(w, code, httpError{Error: err.Error()})
+}
+
+func makeDeviceInfo(d Device) DeviceInfo {
+	info := DeviceInfo{
+		ID:                    d.GetID(),
+		Name:                  d.GetName(),
+		ProtocolIndependentID: d.GetProtocolIndependentID(),
+		ResourceTypes:         d.GetResourceTypes(),
+	}
+	if cm := d.GetCloudManager(); cm != nil {
+		cfg := cm.GetCloudConfiguration()
+		info.Cloud = CloudStatus{
+			Enabled:            true,
+			CloudID:            cfg.CloudID,
+			URL:                cfg.URL,
+			ProvisioningStatus: cfg.ProvisioningStatus,
+			LastErrorCode:      cfg.LastErrorCode,
+		}
+	}
+	return info
+}
+
+func (h *httpAPI) loadDevice(w http.ResponseWriter, r *http.Request) (Device, bool) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid device id: %w", err))
+		return nil, false
+	}
+	d, ok := h.s.GetDevice(id)
+	if !ok {
+		h.writeError(w, http.StatusNotFound, fmt.Errorf("device %v not found", id))
+		return nil, false
+	}
+	return d, true
+}
+
+func (h *httpAPI) listDevices(w http.ResponseWriter, _ *http.Request) {
+	devices := make([]DeviceInfo, 0, h.s.Length())
+	h.s.Range(func(_ uuid.UUID, d Device) bool {
+		devices = append(devices, makeDeviceInfo(d))
+		return true
+	})
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].ID.String() < devices[j].ID.String()
+	})
+	h.writeResponse(w, http.StatusOK, devices)
+}
+
+func (h *httpAPI) createDevice(w http.ResponseWriter, r *http.Request) {
+	if h.newDeviceFromSpec == nil {
+		h.writeError(w, http.StatusNotImplemented, errors.New("creation of devices is not supported"))
+		return
+	}
+	var spec DeviceSpec
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxDeviceSpecSize)).Decode(&spec); err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		h.writeError(w, status, fmt.Errorf("invalid device spec: %w", err))
+		return
+	}
+	if spec.ID == uuid.Nil {
+		spec.ID = uuid.New()
+	}
+	d, loaded, err := h.s.createDevice(spec.ID, func() (Device, error) {
+		return h.newDeviceFromSpec(spec, h.s.getProtocolIndependentID())
+	})
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, fmt.Errorf("cannot create device %v: %w", spec.ID, err))
+		return
+	}
+	if loaded {
+		h.writeError(w, http.StatusConflict, fmt.Errorf("device with id %v already exists", spec.ID))
+		return
+	}
+	d.Init()
+	h.writeResponse(w, http.StatusCreated, makeDeviceInfo(d))
+}
+
+func (h *httpAPI) getDevice(w http.ResponseWriter, r *http.Request) {
+	d, ok := h.loadDevice(w, r)
+	if !ok {
+		return
+	}
+	h.writeResponse(w, http.StatusOK, makeDeviceInfo(d))
+}
+
+func (h *httpAPI) deleteDevice(w http.ResponseWriter, r *http.Request) {
+	d, ok := h.loadDevice(w, r)
+	if !ok {
+		return
+	}
+	if !h.s.DeleteAndCloseDevice(d.GetID()) {
+		h.writeError(w, http.StatusNotFound, fmt.Errorf("device %v not found", d.GetID()))
+		return
+	}
+	h.writeResponse(w, http.StatusNoContent, nil)
+}
+
+func (h *httpAPI) listResources(w http.ResponseWriter, r *http.Request) {
+	d, ok := h.loadDevice(w, r)
+	if !ok {
+		return
+	}
+	resources := make([]ResourceInfo, 0, 16)
+	d.Range(func(_ string, res device.Resource) bool {
+		resources = append(resources, ResourceInfo{
+			Href:          res.GetHref(),
+			ResourceTypes: res.GetResourceTypes(),
+			Interfaces:    res.GetResourceInterfaces(),
+		})
+		return true
+	})
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Href < resources[j].Href
+	})
+	h.writeResponse(w, http.StatusOK, resources)
+}
+
+func (h *httpAPI) handleCloud(w http.ResponseWriter, r *http.Request, f func(d Device)) {
+	d, ok := h.loadDevice(w, r)
+	if !ok {
+		return
+	}
+	if d.GetCloudManager() == nil {
+		h.writeError(w, http.StatusConflict, fmt.Errorf("cloud is not enabled for device %v", d.GetID()))
+		return
+	}
+	f(d)
+	h.writeResponse(w, http.StatusAccepted, makeDeviceInfo(d))
+}
+
+func (h *httpAPI) reconnectCloud(w http.ResponseWriter, r *http.Request) {
+	h.handleCloud(w, r, func(d Device) {
+		d.GetCloudManager().Reconnect()
+	})
+}
+
+func (h *httpAPI) unregisterCloud(w http.ResponseWriter, r *http.Request) {
+	h.handleCloud(w, r, func(d Device) {
+		d.GetCloudManager().Unregister()
+	})
+}
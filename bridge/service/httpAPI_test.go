@@ -0,0 +1,167 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package service_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/device"
+	"github.com/plgd-dev/device/v2/bridge/device/cloud"
+	"github.com/plgd-dev/device/v2/bridge/net"
+	"github.com/plgd-dev/device/v2/bridge/service"
+	schemaCloud "github.com/plgd-dev/device/v2/schema/cloud"
+	schemaDevice "github.com/plgd-dev/device/v2/schema/device"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServiceWithHTTP(t *testing.T, opts ...service.Option) *service.Service {
+	cfg := service.Config{
+		API: service.APIConfig{
+			CoAP: service.CoAPConfig{
+				ID:     "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				Config: net.Config{ExternalAddresses: []string{"127.0.0.1:0"}},
+			},
+			HTTP: service.HTTPConfig{
+				Enabled: true,
+				Address: "127.0.0.1:0",
+			},
+		},
+	}
+	s, err := service.New(cfg, opts...)
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve()
+	}()
+	t.Cleanup(func() {
+		_ = s.Shutdown()
+	})
+	return s
+}
+
+func doHTTPRequest(t *testing.T, s *service.Service, method, path string, body interface{}, resp interface{}) int {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req, err := http.NewRequest(method, "http://"+s.GetHTTPAddress()+path, bytes.NewReader(data))
+	require.NoError(t, err)
+	r, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() {
+		_ = r.Body.Close()
+	}()
+	if resp != nil {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(resp))
+	}
+	return r.StatusCode
+}
+
+func devicePath(id uuid.UUID, path string) string {
+	return strings.Replace(path, "{id}", id.String(), 1)
+}
+
+func TestHTTPAPI(t *testing.T) {
+	s := newTestServiceWithHTTP(t, service.WithNewDeviceFromSpec(func(spec service.DeviceSpec, piid uuid.UUID) (service.Device, error) {
+		return device.New(device.Config{
+			ID:                    spec.ID,
+			Name:                  spec.Name,
+			ProtocolIndependentID: piid,
+			ResourceTypes:         spec.ResourceTypes,
+			Cloud: device.CloudConfig{
+				Enabled: true,
+			},
+		}, device.WithCAPool(cloud.MakeCAPool(nil, true)))
+	}))
+
+	var devices []service.DeviceInfo
+	require.Equal(t, http.StatusOK, doHTTPRequest(t, s, http.MethodGet, service.HTTPDevicesPath, nil, &devices))
+	require.Empty(t, devices)
+
+	var created service.DeviceInfo
+	require.Equal(t, http.StatusCreated, doHTTPRequest(t, s, http.MethodPost, service.HTTPDevicesPath, service.DeviceSpec{
+		Name:          "device",
+		ResourceTypes: []string{"oic.d.virtual"},
+	}, &created))
+	require.NotEqual(t, uuid.Nil, created.ID)
+	require.Equal(t, "device", created.Name)
+	require.True(t, created.Cloud.Enabled)
+	require.Equal(t, schemaCloud.ProvisioningStatus_UNINITIALIZED, created.Cloud.ProvisioningStatus)
+	_, ok := s.GetDevice(created.ID)
+	require.True(t, ok)
+
+	require.Equal(t, http.StatusConflict, doHTTPRequest(t, s, http.MethodPost, service.HTTPDevicesPath, service.DeviceSpec{
+		ID:   created.ID,
+		Name: "duplicate",
+	}, nil))
+	require.Equal(t, http.StatusBadRequest, doHTTPRequest(t, s, http.MethodPost, service.HTTPDevicesPath, "invalid", nil))
+	require.Equal(t, http.StatusRequestEntityTooLarge, doHTTPRequest(t, s, http.MethodPost, service.HTTPDevicesPath, service.DeviceSpec{
+		Name: strings.Repeat("a", 128*1024),
+	}, nil))
+
+	require.Equal(t, http.StatusOK, doHTTPRequest(t, s, http.MethodGet, service.HTTPDevicesPath, nil, &devices))
+	require.Len(t, devices, 1)
+	require.Equal(t, created, devices[0])
+
+	var got service.DeviceInfo
+	require.Equal(t, http.StatusOK, doHTTPRequest(t, s, http.MethodGet, devicePath(created.ID, service.HTTPDevicePath), nil, &got))
+	require.Equal(t, created, got)
+	require.Equal(t, http.StatusBadRequest, doHTTPRequest(t, s, http.MethodGet, devicePath(created.ID, service.HTTPDevicePath)+"x", nil, nil))
+	require.Equal(t, http.StatusNotFound, doHTTPRequest(t, s, http.MethodGet, devicePath(uuid.New(), service.HTTPDevicePath), nil, nil))
+
+	var resources []service.ResourceInfo
+	require.Equal(t, http.StatusOK, doHTTPRequest(t, s, http.MethodGet, devicePath(created.ID, service.HTTPDeviceResourcesPath), nil, &resources))
+	hrefs := make([]string, 0, len(resources))
+	for _, r := range resources {
+		hrefs = append(hrefs, r.Href)
+	}
+	require.Contains(t, hrefs, schemaDevice.ResourceURI)
+	require.Contains(t, hrefs, schemaCloud.ResourceURI)
+
+	require.Equal(t, http.StatusAccepted, doHTTPRequest(t, s, http.MethodPost, devicePath(created.ID, service.HTTPCloudReconnectPath), nil, nil))
+	require.Equal(t, http.StatusAccepted, doHTTPRequest(t, s, http.MethodPost, devicePath(created.ID, service.HTTPCloudUnregisterPath), nil, nil))
+
+	require.Equal(t, http.StatusNoContent, doHTTPRequest(t, s, http.MethodDelete, devicePath(created.ID, service.HTTPDevicePath), nil, nil))
+	require.Equal(t, http.StatusNotFound, doHTTPRequest(t, s, http.MethodDelete, devicePath(created.ID, service.HTTPDevicePath), nil, nil))
+	require.Equal(t, 0, s.Length())
+}
+
+func TestHTTPAPIWithoutDeviceFactory(t *testing.T) {
+	s := newTestServiceWithHTTP(t)
+	d, err := s.CreateDevice(uuid.New(), func(id uuid.UUID, piid uuid.UUID) (service.Device, error) {
+		return device.New(device.Config{
+			ID:                    id,
+			ProtocolIndependentID: piid,
+		})
+	})
+	require.NoError(t, err)
+	d.Init()
+
+	require.Equal(t, http.StatusNotImplemented, doHTTPRequest(t, s, http.MethodPost, service.HTTPDevicesPath, service.DeviceSpec{Name: "device"}, nil))
+	var got service.DeviceInfo
+	require.Equal(t, http.StatusOK, doHTTPRequest(t, s, http.MethodGet, devicePath(d.GetID(), service.HTTPDevicePath), nil, &got))
+	require.False(t, got.Cloud.Enabled)
+	require.Equal(t, http.StatusConflict, doHTTPRequest(t, s, http.MethodPost, devicePath(d.GetID(), service.HTTPCloudReconnectPath), nil, nil))
+}
@@ -26,6 +26,8 @@ import (
 type OptionsCfg struct {
 	onDiscoveryDevices func(*net.Request)
 	logger             log.Logger
+	store              Store
+	newDeviceFromSpec  NewDeviceFromSpecFunc
 }
 
 func WithOnDiscoveryDevices(f func(*net.Request)) Option {
@@ -42,4 +44,18 @@ func WithLogger(logger log.Logger) Option {
 	}
 }
 
+// WithStore persists the configuration of the devices to the store, see Service.RestoreDevices.
+func WithStore(store Store) Option {
+	return func(o *OptionsCfg) {
+		o.store = store
+	}
+}
+
+// WithNewDeviceFromSpec allows to create devices by the management API.
+func WithNewDeviceFromSpec(f NewDeviceFromSpecFunc) Option {
+	return func(o *OptionsCfg) {
+		o.newDeviceFromSpec = f
+	}
+}
+
 type Option func(*OptionsCfg)
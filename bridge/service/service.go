@@ -26,6 +26,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/plgd-dev/device/v2/bridge/device"
 	"github.com/plgd-dev/device/v2/bridge/device/cloud"
+	"github.com/plgd-dev/device/v2/bridge/device/security"
 	"github.com/plgd-dev/device/v2/bridge/device/thingDescription"
 	"github.com/plgd-dev/device/v2/bridge/net"
 	"github.com/plgd-dev/device/v2/bridge/resources"
@@ -33,6 +34,7 @@ import (
 	"github.com/plgd-dev/device/v2/pkg/eventloop"
 	"github.com/plgd-dev/device/v2/pkg/log"
 	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/pstat"
 	plgdResources "github.com/plgd-dev/device/v2/schema/resources"
 	"github.com/plgd-dev/go-coap/v3/message"
 	"github.com/plgd-dev/go-coap/v3/message/codes"
@@ -64,6 +66,8 @@ type Device interface {
 	GetCloudManager() *cloud.Manager
 	GetLoop() *eventloop.Loop
 	GetThingDescriptionManager() *thingDescription.Manager
+	GetSecurityManager() *security.Manager
+	GetPreSharedKey(identity []byte) ([]byte, bool)
 }
 
 type Service struct {
@@ -72,6 +76,9 @@ type Service struct {
 	devices            *coapSync.Map[uuid.UUID, Device]
 	wg                 sync.WaitGroup
 	onDiscoveryDevices func(req *net.Request)
+	store              Store
+	logger             log.Logger
+	http               *httpAPI
 }
 
 func (c *Service) LoadDevice(di uuid.UUID) (Device, error) {
@@ -90,17 +97,51 @@ func (c *Service) handleDiscoverAllLinks(req *net.Request) (*pool.Message, error
 	res := discovery.New(plgdResources.ResourceURI, func(*net.Request) schema.ResourceLinks {
 		links := make(schema.ResourceLinks, 0, c.devices.Length()+1)
 		for _, d := range c.devices.CopyData() {
-			dlinks := d.GetLinks(req)
+			dlinks := d.GetLinks(c.withDeviceEndpoints(req, d.GetID()))
 			if len(dlinks) > 0 {
 				links = append(links, dlinks...)
 			}
 		}
 		return links
-	})
+	}, nil)
 	defer res.Close()
 	return res.Get(req)
 }
 
+// getPreSharedKey returns the pre-shared key provisioned by the owner of the bridged device. The owner provisions
+// a different key to each device, so the key is selected only by the listener of the device.
+func (c *Service) getPreSharedKey(deviceID uuid.UUID, identity []byte) ([]byte, error) {
+	if deviceID == uuid.Nil {
+		return nil, errors.New("pre-shared key is not supported by the shared listener")
+	}
+	d, err := c.LoadDevice(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	psk, ok := d.GetPreSharedKey(identity)
+	if !ok {
+		return nil, fmt.Errorf("pre-shared key for identity %x not found at device %v", identity, deviceID)
+	}
+	return psk, nil
+}
+
+func isReadyForOwnershipTransfer(d Device) bool {
+	sm := d.GetSecurityManager()
+	return sm != nil && sm.GetOperationalState() == pstat.OperationalState_RFOTM
+}
+
+// allowJustWorks offers the just works cipher suite only on the listener of the bridged device during its ownership transfer.
+func (c *Service) allowJustWorks(deviceID uuid.UUID) bool {
+	if deviceID == uuid.Nil {
+		return false
+	}
+	d, err := c.LoadDevice(deviceID)
+	if err != nil {
+		return false
+	}
+	return isReadyForOwnershipTransfer(d)
+}
+
 func (c *Service) DefaultRequestHandler(req *net.Request) (*pool.Message, error) {
 	uriPath := req.URIPath()
 	if uriPath == "" {
@@ -124,7 +165,18 @@ func (c *Service) DefaultRequestHandler(req *net.Request) (*pool.Message, error)
 		}
 		return nil, err
 	}
-	return d.HandleRequest(req)
+	return d.HandleRequest(c.withDeviceEndpoints(req, deviceID))
+}
+
+// withDeviceEndpoints returns the request with the endpoints of the device, the request received on the shared listener
+// announces the listeners of the device instead of the shared ones.
+func (c *Service) withDeviceEndpoints(req *net.Request, deviceID uuid.UUID) *net.Request {
+	if req.ListenerDeviceID != uuid.Nil || req.Conn == nil {
+		return req
+	}
+	r := *req
+	r.Endpoints = c.net.GetDeviceEndpoints(deviceID, req.ControlMessage(), req.Conn.NetConn().LocalAddr().String())
+	return &r
 }
 
 func New(cfg Config, opts ...Option) (*Service, error) {
@@ -147,12 +199,28 @@ func New(cfg Config, opts ...Option) (*Service, error) {
 		cfg:                cfg,
 		devices:            coapSync.NewMap[uuid.UUID, Device](),
 		onDiscoveryDevices: o.onDiscoveryDevices,
+		store:              o.store,
+		logger:             o.logger,
+	}
+	if cfg.API.CoAP.DTLS.PSK == nil {
+		cfg.API.CoAP.DTLS.PSK = c.getPreSharedKey
+	}
+	if cfg.API.CoAP.DTLS.AllowJustWorks == nil {
+		cfg.API.CoAP.DTLS.AllowJustWorks = c.allowJustWorks
 	}
 	n, err := net.New(cfg.API.CoAP.Config, c.DefaultRequestHandler, o.logger)
 	if err != nil {
 		return nil, err
 	}
 	c.net = n
+	if cfg.API.HTTP.Enabled {
+		h, errH := newHTTPAPI(cfg.API.HTTP, &c, o.newDeviceFromSpec, o.logger)
+		if errH != nil {
+			_ = n.Close()
+			return nil, errH
+		}
+		c.http = h
+	}
 
 	return &c, nil
 }
@@ -160,10 +228,32 @@ func New(cfg Config, opts ...Option) (*Service, error) {
 func (c *Service) Serve() error {
 	c.wg.Add(1)
 	defer c.wg.Done()
+	if c.http != nil {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			if err := c.http.serve(); err != nil {
+				c.logger.Errorf("http server: %w", err)
+			}
+		}()
+	}
 	return c.net.Serve()
 }
 
+// GetHTTPAddress returns the address of the management API, it is empty when the API is disabled.
+func (c *Service) GetHTTPAddress() string {
+	if c.http == nil {
+		return ""
+	}
+	return c.http.getAddress()
+}
+
 func (c *Service) Shutdown() error {
+	if c.http != nil {
+		if err := c.http.close(); err != nil {
+			return err
+		}
+	}
 	err := c.net.Close()
 	if err != nil {
 		return err
@@ -176,54 +266,126 @@ func (c *Service) Shutdown() error {
 	return nil
 }
 
-type NewDeviceFunc func(id uuid.UUID, piid uuid.UUID) (Device, error)
+type (
+	NewDeviceFunc     func(id uuid.UUID, piid uuid.UUID) (Device, error)
+	RestoreDeviceFunc func(cfg device.Config) (Device, error)
+)
 
-func (c *Service) CreateDevice(id uuid.UUID, newDevice NewDeviceFunc) (Device, error) {
-	var d Device
-	var err error
-	_, oldLoaded := c.devices.ReplaceWithFunc(id, func(oldValue Device, oldLoaded bool) (newValue Device, doDelete bool) {
+func (c *Service) createDevice(id uuid.UUID, newDevice func() (Device, error)) (d Device, loaded bool, err error) {
+	oldDevice, oldLoaded := c.devices.ReplaceWithFunc(id, func(oldValue Device, oldLoaded bool) (newValue Device, doDelete bool) {
 		if oldLoaded {
 			return oldValue, false
 		}
-		d, err = newDevice(id, resources.ToUUID(c.cfg.API.CoAP.ID))
+		d, err = newDevice()
 		if err != nil {
-			if oldLoaded {
-				return oldValue, false
-			}
+			return nil, true
+		}
+		if err = c.listenDevice(d); err != nil {
 			return nil, true
 		}
 		return d, false
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	if oldLoaded {
+		return oldDevice, true, nil
+	}
+	c.saveDevice(d)
+	return d, false, nil
+}
+
+// listenDevice creates the listeners of the device with the security resources, the clients select the device by them
+// when they establish the secure connection, e.g. the pre-shared key or the just works cipher suite.
+func (c *Service) listenDevice(d Device) error {
+	if d.GetSecurityManager() == nil {
+		return nil
+	}
+	if err := c.net.ListenDevice(d.GetID()); err != nil {
+		return fmt.Errorf("cannot create listeners of device %v: %w", d.GetID(), err)
+	}
+	return nil
+}
+
+func (c *Service) closeDeviceListeners(d Device) {
+	if d.GetSecurityManager() == nil {
+		return
+	}
+	if err := c.net.CloseDevice(d.GetID()); err != nil {
+		c.logger.Errorf("cannot close listeners of device %v: %w", d.GetID(), err)
+	}
+}
+
+func (c *Service) getProtocolIndependentID() uuid.UUID {
+	return resources.ToUUID(c.cfg.API.CoAP.ID)
+}
+
+func (c *Service) CreateDevice(id uuid.UUID, newDevice NewDeviceFunc) (Device, error) {
+	d, loaded, err := c.createDevice(id, func() (Device, error) {
+		return newDevice(id, c.getProtocolIndependentID())
+	})
+	if err != nil {
+		return nil, err
+	}
+	if loaded {
 		return nil, fmt.Errorf("device with id %v already exists", id)
 	}
 	return d, nil
 }
 
 func (c *Service) GetOrCreateDevice(id uuid.UUID, newDevice NewDeviceFunc) (d Device, loaded bool, err error) {
-	oldDevice, oldLoaded := c.devices.ReplaceWithFunc(id, func(oldValue Device, oldLoaded bool) (newValue Device, doDelete bool) {
-		if oldLoaded {
-			return oldValue, false
-		}
-		d, err = newDevice(id, resources.ToUUID(c.cfg.API.CoAP.ID))
+	return c.createDevice(id, func() (Device, error) {
+		return newDevice(id, c.getProtocolIndependentID())
+	})
+}
+
+// RestoreDevices creates the devices stored in the store with their ID, cloud registration and credentials. The
+// devices are not initialized, the caller is responsible for adding resources and calling Init.
+func (c *Service) RestoreDevices(restoreDevice RestoreDeviceFunc) ([]Device, error) {
+	if c.store == nil {
+		return nil, nil
+	}
+	cfgs, loadErr := c.store.Load()
+	piid := c.getProtocolIndependentID()
+	devices := make([]Device, 0, len(cfgs))
+	var errs []error
+	if loadErr != nil {
+		errs = append(errs, loadErr)
+	}
+	for _, cfg := range cfgs {
+		cfg.ProtocolIndependentID = piid
+		d, loaded, err := c.createDevice(cfg.ID, func() (Device, error) {
+			return restoreDevice(cfg)
+		})
 		if err != nil {
-			if oldLoaded {
-				return oldValue, false
-			}
-			return nil, true
+			errs = append(errs, fmt.Errorf("cannot restore device %v: %w", cfg.ID, err))
+			continue
 		}
-		return d, false
-	})
-	if err != nil {
-		return nil, false, err
+		if loaded {
+			errs = append(errs, fmt.Errorf("cannot restore device %v: device already exists", cfg.ID))
+			continue
+		}
+		devices = append(devices, d)
 	}
-	if oldLoaded {
-		return oldDevice, true, nil
+	return devices, errors.Join(errs...)
+}
+
+func (c *Service) saveDevice(d Device) {
+	if c.store == nil {
+		return
 	}
-	return d, false, nil
+	if err := c.store.Save(d.ExportConfig()); err != nil {
+		c.logger.Errorf("cannot save device %v: %w", d.GetID(), err)
+	}
+}
+
+// OnDeviceUpdated stores the actual configuration of the device, use it with device.WithOnDeviceUpdated.
+func (c *Service) OnDeviceUpdated(d *device.Device) {
+	if _, ok := c.devices.Load(d.GetID()); !ok {
+		// device has been deleted
+		return
+	}
+	c.saveDevice(d)
 }
 
 func (c *Service) GetDevice(id uuid.UUID) (Device, bool) {
@@ -247,13 +409,23 @@ func (c *Service) Length() int {
 }
 
 func (c *Service) GetAndDeleteDevice(id uuid.UUID) (Device, bool) {
-	return c.devices.LoadAndDelete(id)
+	d, ok := c.devices.LoadAndDelete(id)
+	if ok {
+		c.closeDeviceListeners(d)
+	}
+	return d, ok
 }
 
 func (c *Service) DeleteAndCloseDevice(id uuid.UUID) bool {
 	d, ok := c.devices.LoadAndDelete(id)
 	if ok {
+		c.closeDeviceListeners(d)
 		d.Close()
 	}
+	if c.store != nil {
+		if err := c.store.Delete(id); err != nil && !errors.Is(err, ErrDeviceNotStored) {
+			c.logger.Errorf("cannot delete device %v from store: %w", id, err)
+		}
+	}
 	return ok
 }
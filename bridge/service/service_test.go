@@ -0,0 +1,249 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package service_test
+
+import (
+	"context"
+	gonet "net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pion/dtls/v3"
+	"github.com/plgd-dev/device/v2/bridge/device"
+	"github.com/plgd-dev/device/v2/bridge/net"
+	"github.com/plgd-dev/device/v2/bridge/service"
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/client/core/otm"
+	justworks "github.com/plgd-dev/device/v2/client/core/otm/just-works"
+	"github.com/plgd-dev/device/v2/client/core/otm/just-works/cipher"
+	"github.com/plgd-dev/device/v2/pkg/log"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	schemaDevice "github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T, store service.Store) *service.Service {
+	cfg := service.Config{
+		API: service.APIConfig{
+			CoAP: service.CoAPConfig{
+				ID:     "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				Config: net.Config{ExternalAddresses: []string{"127.0.0.1:0"}},
+			},
+		},
+	}
+	s, err := service.New(cfg, service.WithStore(store))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = s.Shutdown()
+	})
+	return s
+}
+
+func TestRestoreDevices(t *testing.T) {
+	store := service.NewMemoryStore()
+	s := newTestService(t, store)
+	newDevice := func(id uuid.UUID, piid uuid.UUID) (service.Device, error) {
+		return device.New(device.Config{
+			ID:                    id,
+			Name:                  "bridged-device",
+			ProtocolIndependentID: piid,
+			ResourceTypes:         []string{"oic.d.virtual"},
+			Credential: device.CredentialConfig{
+				Enabled: true,
+				Config: credential.CredentialResponse{
+					Credentials: []credential.Credential{
+						{
+							ID:      1,
+							Subject: uuid.NewString(),
+							Type:    credential.CredentialType_PIN_OR_PASSWORD,
+						},
+					},
+				},
+			},
+		}, device.WithOnDeviceUpdated(s.OnDeviceUpdated))
+	}
+	d1, err := s.CreateDevice(uuid.New(), newDevice)
+	require.NoError(t, err)
+	d2, err := s.CreateDevice(uuid.New(), newDevice)
+	require.NoError(t, err)
+	cfgs, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, cfgs, 2)
+
+	require.True(t, s.DeleteAndCloseDevice(d2.GetID()))
+	cfgs, err = store.Load()
+	require.NoError(t, err)
+	require.Len(t, cfgs, 1)
+	require.Equal(t, d1.GetID(), cfgs[0].ID)
+	require.Len(t, cfgs[0].Credential.Credentials, 1)
+
+	// restore devices in the new instance of the service
+	s2 := newTestService(t, store)
+	restored, err := s2.RestoreDevices(func(cfg device.Config) (service.Device, error) {
+		return device.New(cfg, device.WithOnDeviceUpdated(s2.OnDeviceUpdated))
+	})
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	require.Equal(t, d1.GetID(), restored[0].GetID())
+	require.Equal(t, d1.GetProtocolIndependentID(), restored[0].GetProtocolIndependentID())
+	require.Equal(t, d1.ExportConfig().Credential, restored[0].ExportConfig().Credential)
+	_, ok := s2.GetDevice(d1.GetID())
+	require.True(t, ok)
+
+	// restored device cannot be restored again
+	_, err = s2.RestoreDevices(func(cfg device.Config) (service.Device, error) {
+		return device.New(cfg)
+	})
+	require.Error(t, err)
+}
+
+func getFreeUDPAddress(t *testing.T) string {
+	l, err := gonet.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		_ = l.Close()
+	}()
+	return "127.0.0.1:" + strconv.Itoa(l.LocalAddr().(*gonet.UDPAddr).Port)
+}
+
+func TestOwnDevices(t *testing.T) {
+	coapAddr := getFreeUDPAddress(t)
+	coapsAddr := getFreeUDPAddress(t)
+	cfg := service.Config{
+		API: service.APIConfig{
+			CoAP: service.CoAPConfig{
+				ID: "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+				Config: net.Config{
+					ExternalAddresses: []string{coapAddr},
+					DTLS: net.DTLSConfig{
+						ExternalAddresses: []string{coapsAddr},
+						JustWorks:         true,
+					},
+				},
+			},
+		},
+	}
+	s, err := service.New(cfg)
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve()
+	}()
+	t.Cleanup(func() {
+		_ = s.Shutdown()
+	})
+	newDevice := func(id uuid.UUID, piid uuid.UUID) (service.Device, error) {
+		return device.New(device.Config{
+			ID:                    id,
+			Name:                  "bridged-device",
+			ProtocolIndependentID: piid,
+			ResourceTypes:         []string{"oic.d.virtual"},
+			Credential:            device.CredentialConfig{Enabled: true},
+			Security:              device.SecurityConfig{Enabled: true},
+		})
+	}
+	devices := make([]service.Device, 0, 2)
+	for range 2 {
+		d, errC := s.CreateDevice(uuid.New(), newDevice)
+		require.NoError(t, errC)
+		d.Init()
+		devices = append(devices, d)
+	}
+
+	ownerID := uuid.New()
+	deviceCfg := core.DeviceConfiguration{
+		DialDTLS: coap.DialUDPSecure,
+		DialTLS:  coap.DialTCPSecure,
+		DialUDP:  coap.DialUDP,
+		DialTCP:  coap.DialTCP,
+		Logger:   log.NewNilLogger(),
+		GetOwnerID: func() (string, error) {
+			return ownerID.String(), nil
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	// the owner provisions a different pre-shared key to each device
+	psks := [][]byte{[]byte("0123456789abcdef"), []byte("fedcba9876543210")}
+	deviceLinks := make([]schema.ResourceLink, 0, len(devices))
+	for _, d := range devices {
+		deviceID := d.GetID().String()
+		cd := core.NewDevice(deviceCfg, deviceID, nil, func() schema.Endpoints {
+			return schema.Endpoints{{URI: "coap://" + coapAddr}}
+		})
+		links, errG := cd.GetResourceLinks(ctx, cd.GetEndpoints(), coap.WithDeviceID(deviceID))
+		require.NoError(t, errG)
+		require.NoError(t, cd.Close(ctx))
+		link, ok := links.GetResourceLink(schemaDevice.ResourceURI)
+		require.True(t, ok)
+		deviceLinks = append(deviceLinks, link)
+	}
+	// the client owns the devices by their endpoints, as any other device
+	for i, link := range deviceLinks {
+		cd := core.NewDevice(deviceCfg, devices[i].GetID().String(), nil, link.GetEndpoints)
+		links, errG := cd.GetResourceLinks(ctx, cd.GetEndpoints())
+		require.NoError(t, errG)
+		errO := cd.Own(ctx, links, []otm.Client{justworks.NewClient()}, core.WithPresharedKey(psks[i]))
+		require.NoError(t, errO)
+		require.NoError(t, cd.Close(ctx))
+	}
+
+	ownerBin, err := ownerID.MarshalBinary()
+	require.NoError(t, err)
+	for i, d := range devices {
+		require.True(t, d.GetSecurityManager().IsOwned())
+		deviceID := d.GetID().String()
+		addr, errA := deviceLinks[i].GetUDPSecureAddr()
+		require.NoError(t, errA)
+		require.NotEqual(t, coapsAddr, addr.String())
+		conn, errD := coap.DialUDPSecure(ctx, addr.String(), &dtls.Config{
+			PSKIdentityHint: ownerBin,
+			PSK: func([]byte) ([]byte, error) {
+				return psks[i], nil
+			},
+			CipherSuites: []dtls.CipherSuiteID{dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256},
+		})
+		require.NoError(t, errD)
+		var ownership doxm.Doxm
+		errG := conn.GetResource(ctx, doxm.ResourceURI, &ownership)
+		require.NoError(t, errG)
+		require.Equal(t, ownerID.String(), ownership.OwnerID)
+		require.Equal(t, deviceID, ownership.DeviceID)
+		require.NoError(t, conn.Close())
+	}
+
+	// the just works cipher suite is offered only while the device is ready for ownership transfer
+	addr, err := deviceLinks[0].GetUDPSecureAddr()
+	require.NoError(t, err)
+	for _, a := range []string{addr.String(), coapsAddr} {
+		dialCtx, dialCancel := context.WithTimeout(ctx, time.Second)
+		_, errD := coap.DialUDPSecure(dialCtx, a, &dtls.Config{
+			CustomCipherSuites: func() []dtls.CipherSuite {
+				return []dtls.CipherSuite{cipher.NewTLSAecdhAes128Sha256(dtls.CipherSuiteID(0xff00))}
+			},
+			CipherSuites: []dtls.CipherSuiteID{},
+		})
+		dialCancel()
+		require.Error(t, errD)
+	}
+}
@@ -0,0 +1,82 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package service
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/device"
+)
+
+var ErrDeviceNotStored = errors.New("device is not stored")
+
+// Store persists the configuration of the bridged devices, so they can be restored with the same ID, cloud registration
+// and credentials after the restart of the service.
+type Store interface {
+	// Load returns the configurations of all stored devices.
+	Load() ([]device.Config, error)
+	// Save stores the configuration of the device, the previous configuration of the device is replaced.
+	Save(cfg device.Config) error
+	// Delete removes the configuration of the device, ErrDeviceNotStored is returned when the device is not stored.
+	Delete(id uuid.UUID) error
+}
+
+// MemoryStore keeps the configurations of the devices in memory, it is intended for tests.
+type MemoryStore struct {
+	lock    sync.Mutex
+	devices map[uuid.UUID]device.Config
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		devices: make(map[uuid.UUID]device.Config),
+	}
+}
+
+func (s *MemoryStore) Load() ([]device.Config, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	cfgs := make([]device.Config, 0, len(s.devices))
+	for _, cfg := range s.devices {
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs, nil
+}
+
+func (s *MemoryStore) Save(cfg device.Config) error {
+	if cfg.ID == uuid.Nil {
+		return errors.New("invalid device id")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.devices[cfg.ID] = cfg
+	return nil
+}
+
+func (s *MemoryStore) Delete(id uuid.UUID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.devices[id]; !ok {
+		return ErrDeviceNotStored
+	}
+	delete(s.devices, id)
+	return nil
+}
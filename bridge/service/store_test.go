@@ -0,0 +1,143 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/bridge/device"
+	"github.com/plgd-dev/device/v2/bridge/device/cloud"
+	"github.com/plgd-dev/device/v2/bridge/service"
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/stretchr/testify/require"
+)
+
+func makeStoredDeviceConfig(name string) device.Config {
+	return device.Config{
+		ID:                    uuid.New(),
+		Name:                  name,
+		ProtocolIndependentID: uuid.New(),
+		ResourceTypes:         []string{"oic.d.virtual"},
+		MaxMessageSize:        1024,
+		Cloud: device.CloudConfig{
+			Enabled: true,
+			Config: cloud.Config{
+				AccessToken:           "accessToken",
+				UserID:                "userID",
+				RefreshToken:          "refreshToken",
+				ValidUntil:            time.Unix(1700000000, 0),
+				AuthorizationProvider: "plgd",
+				CloudID:               uuid.NewString(),
+				CloudURL:              "coaps+tcp://localhost:5684",
+			},
+		},
+		Credential: device.CredentialConfig{
+			Enabled: true,
+			Config: credential.CredentialResponse{
+				ResourceOwner: uuid.NewString(),
+				Credentials: []credential.Credential{
+					{
+						ID:      1,
+						Subject: uuid.NewString(),
+						Type:    credential.CredentialType_SYMMETRIC_PAIR_WISE,
+						PrivateData: &credential.CredentialPrivateData{
+							DataInternal: []byte{0x00, 0x01, 0xfe, 0xff},
+							Encoding:     credential.CredentialPrivateDataEncoding_RAW,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func requireEqualDeviceConfig(t *testing.T, expected, actual device.Config) {
+	require.True(t, expected.Cloud.ValidUntil.Equal(actual.Cloud.ValidUntil))
+	actual.Cloud.ValidUntil = expected.Cloud.ValidUntil
+	require.Equal(t, expected, actual)
+}
+
+func testStore(t *testing.T, s service.Store) {
+	cfgs, err := s.Load()
+	require.NoError(t, err)
+	require.Empty(t, cfgs)
+
+	cfg1 := makeStoredDeviceConfig("device1")
+	require.NoError(t, s.Save(cfg1))
+	cfg2 := makeStoredDeviceConfig("device2")
+	require.NoError(t, s.Save(cfg2))
+	cfg1.Cloud.AccessToken = "newAccessToken"
+	require.NoError(t, s.Save(cfg1))
+	require.Error(t, s.Save(device.Config{}))
+
+	cfgs, err = s.Load()
+	require.NoError(t, err)
+	require.Len(t, cfgs, 2)
+	for _, cfg := range cfgs {
+		switch cfg.ID {
+		case cfg1.ID:
+			requireEqualDeviceConfig(t, cfg1, cfg)
+			require.Equal(t, []byte{0x00, 0x01, 0xfe, 0xff}, cfg.Credential.Credentials[0].PrivateData.Data())
+		case cfg2.ID:
+			requireEqualDeviceConfig(t, cfg2, cfg)
+		default:
+			require.Failf(t, "unexpected device", "device %v", cfg.ID)
+		}
+	}
+
+	require.NoError(t, s.Delete(cfg1.ID))
+	require.ErrorIs(t, s.Delete(cfg1.ID), service.ErrDeviceNotStored)
+	cfgs, err = s.Load()
+	require.NoError(t, err)
+	require.Len(t, cfgs, 1)
+	require.Equal(t, cfg2.ID, cfgs[0].ID)
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, service.NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := service.NewFileStore(dir)
+	require.NoError(t, err)
+	testStore(t, s)
+
+	// temporary and foreign files are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "."+uuid.NewString()+".cbor-123.tmp"), []byte("invalid"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("invalid"), 0o600))
+	cfgs, err := s.Load()
+	require.NoError(t, err)
+	require.Len(t, cfgs, 1)
+
+	// corrupted file is reported, but the other devices are loaded
+	require.NoError(t, os.WriteFile(filepath.Join(dir, uuid.NewString()+".cbor"), []byte("invalid"), 0o600))
+	cfgs, err = s.Load()
+	require.Error(t, err)
+	require.Len(t, cfgs, 1)
+}
+
+func TestNewFileStoreWithoutDirectory(t *testing.T) {
+	_, err := service.NewFileStore("")
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema/ael"
+)
+
+// AccessEventLogObservationHandler receives the events of ObserveAccessEventLog.
+type AccessEventLogObservationHandler = interface {
+	// Handle is called with the events which were not reported by the previous notification.
+	Handle(ctx context.Context, deviceID string, events []ael.Event)
+	OnClose()
+	Error(err error)
+}
+
+// GetAccessEventLog returns the auditable event list of the device.
+func (c *Client) GetAccessEventLog(ctx context.Context, deviceID string, opts ...CommonCommandOption) (ael.AccessEventLog, error) {
+	cfg := applyCommonOptions(opts...)
+	d, links, err := c.GetDevice(ctx, deviceID, WithDiscoveryConfiguration(cfg.discoveryConfiguration))
+	if err != nil {
+		return ael.AccessEventLog{}, err
+	}
+	link, err := core.GetResourceLink(links, ael.ResourceURI)
+	if err != nil {
+		return ael.AccessEventLog{}, err
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	if c.useDeviceIDInQuery {
+		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
+	}
+	var resp ael.AccessEventLog
+	err = d.GetResource(ctx, link, &resp, cfg.opts...)
+	if err != nil {
+		return ael.AccessEventLog{}, err
+	}
+	return resp, nil
+}
+
+type accessEventLogObserver struct {
+	deviceID string
+	handler  AccessEventLogObservationHandler
+
+	lock sync.Mutex
+	seen map[string]struct{}
+}
+
+func newAccessEventLogObserver(deviceID string, handler AccessEventLogObservationHandler) *accessEventLogObserver {
+	return &accessEventLogObserver{
+		deviceID: deviceID,
+		handler:  handler,
+	}
+}
+
+// newEventsLocked returns the events which are not in the previous notification. Only the keys of the last
+// notification are kept, so the memory is bounded by the size of the log on the device.
+func (o *accessEventLogObserver) newEventsLocked(events []ael.Event) []ael.Event {
+	seen := make(map[string]struct{}, len(events))
+	newEvents := make([]ael.Event, 0, len(events))
+	for _, e := range events {
+		key := e.Key()
+		seen[key] = struct{}{}
+		if _, ok := o.seen[key]; !ok {
+			newEvents = append(newEvents, e)
+		}
+	}
+	o.seen = seen
+	return newEvents
+}
+
+func (o *accessEventLogObserver) Handle(ctx context.Context, body coap.DecodeFunc) {
+	var log ael.AccessEventLog
+	if err := body(&log); err != nil {
+		o.handler.Error(fmt.Errorf("cannot decode access event log of device %v: %w", o.deviceID, err))
+		return
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	events := o.newEventsLocked(log.Events)
+	if len(events) == 0 {
+		return
+	}
+	o.handler.Handle(ctx, o.deviceID, events)
+}
+
+func (o *accessEventLogObserver) OnClose() {
+	o.handler.OnClose()
+}
+
+func (o *accessEventLogObserver) Error(err error) {
+	o.handler.Error(err)
+}
+
+// ObserveAccessEventLog observes the auditable event list of the device, the handler receives only the newly logged events.
+// The first notification contains all events of the log. The observation is stopped by StopObservingResource.
+func (c *Client) ObserveAccessEventLog(ctx context.Context, deviceID string, handler AccessEventLogObservationHandler, opts ...ObserveOption) (string, error) {
+	return c.ObserveResource(ctx, deviceID, ael.ResourceURI, newAccessEventLogObserver(deviceID, handler), opts...)
+}
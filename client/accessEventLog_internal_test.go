@@ -0,0 +1,75 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/plgd-dev/device/v2/schema/ael"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAccessEventLogHandler struct {
+	events [][]ael.Event
+	closed bool
+	err    error
+}
+
+func (h *mockAccessEventLogHandler) Handle(_ context.Context, _ string, events []ael.Event) {
+	h.events = append(h.events, events)
+}
+
+func (h *mockAccessEventLogHandler) OnClose() { h.closed = true }
+
+func (h *mockAccessEventLogHandler) Error(err error) { h.err = err }
+
+func notifyAccessEventLog(o *accessEventLogObserver, events ...ael.Event) {
+	o.Handle(context.Background(), func(v interface{}) error {
+		log, ok := v.(*ael.AccessEventLog)
+		if !ok {
+			return errors.New("invalid type")
+		}
+		*log = ael.AccessEventLog{
+			Events: events,
+		}
+		return nil
+	})
+}
+
+func TestAccessEventLogObserver(t *testing.T) {
+	h := &mockAccessEventLogHandler{}
+	o := newAccessEventLogObserver("deviceID", h)
+	e1 := ael.Event{ID: "1", Message: "first"}
+	e2 := ael.Event{ID: "2", Message: "second"}
+	e3 := ael.Event{Timestamp: "2026-01-01T00:00:00Z", Message: "third"}
+
+	notifyAccessEventLog(o, e1)
+	notifyAccessEventLog(o, e1)
+	notifyAccessEventLog(o, e1, e2)
+	// the oldest event was dropped by the device
+	notifyAccessEventLog(o, e2, e3)
+	require.Equal(t, [][]ael.Event{{e1}, {e2}, {e3}}, h.events)
+
+	o.Handle(context.Background(), func(interface{}) error {
+		return errors.New("decode error")
+	})
+	require.Error(t, h.err)
+	o.OnClose()
+	require.True(t, h.closed)
+}
@@ -0,0 +1,156 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/platform"
+	"github.com/plgd-dev/device/v2/schema/resources"
+)
+
+// provisionDevice runs the function in the provisioning state (RFPRO) of the device.
+func (c *Client) provisionDevice(ctx context.Context, deviceID string, opts []CommonCommandOption, fn func(ctx context.Context, p *core.ProvisioningClient) error) error {
+	return c.provisionDeviceWithOptions(ctx, deviceID, applyCommonOptions(opts...), fn)
+}
+
+func (c *Client) provisionDeviceWithOptions(ctx context.Context, deviceID string, cfg commonCommandOptions, fn func(ctx context.Context, p *core.ProvisioningClient) error) error {
+	d, links, err := c.GetDevice(ctx, deviceID, WithDiscoveryConfiguration(cfg.discoveryConfiguration))
+	if err != nil {
+		return err
+	}
+	if !d.IsSecured() {
+		return core.MakeUnavailable(fmt.Errorf("device %v is not secured", deviceID))
+	}
+	if c.useDeviceIDInQuery {
+		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
+	}
+	p, err := d.Provision(ctx, links, cfg.opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errC := p.Close(ctx); errC != nil {
+			c.logger.Debugf("provision device %v error: %w", deviceID, errC)
+		}
+	}()
+	return fn(ctx, p)
+}
+
+// ListACLs returns the access control entries of the device.
+func (c *Client) ListACLs(ctx context.Context, deviceID string, opts ...CommonCommandOption) ([]acl.AccessControl, error) {
+	cfg := applyCommonOptions(opts...)
+	d, links, err := c.GetDevice(ctx, deviceID, WithDiscoveryConfiguration(cfg.discoveryConfiguration))
+	if err != nil {
+		return nil, err
+	}
+	link, err := core.GetResourceLink(links, acl.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	if c.useDeviceIDInQuery {
+		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
+	}
+	var resp acl.Response
+	err = d.GetResource(ctx, link, &resp, cfg.opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp.AccessControlList, nil
+}
+
+// AddACE appends the access control entries to the device, the IDs of the entries are assigned by the device.
+func (c *Client) AddACE(ctx context.Context, deviceID string, accessControls []acl.AccessControl, opts ...CommonCommandOption) error {
+	return c.provisionDevice(ctx, deviceID, opts, func(ctx context.Context, p *core.ProvisioningClient) error {
+		return p.AddAccessControls(ctx, accessControls...)
+	})
+}
+
+// DeleteACE removes the access control entry with the aceID from the device.
+func (c *Client) DeleteACE(ctx context.Context, deviceID string, aceID int, opts ...CommonCommandOption) error {
+	return c.provisionDevice(ctx, deviceID, opts, func(ctx context.Context, p *core.ProvisioningClient) error {
+		return p.DeleteAccessControl(ctx, aceID)
+	})
+}
+
+// isOwnerACE returns true for the access control entries which are set by the ownership transfer: the entries
+// of the owner and the entries which allow the anonymous discovery of the device.
+func isOwnerACE(ac acl.AccessControl, ownerID string) bool {
+	if ac.Subject.Subject_Device != nil {
+		return ac.Subject.Subject_Device.DeviceID == ownerID
+	}
+	if ac.Subject.Subject_Connection == nil || ac.Subject.Subject_Connection.Type != acl.ConnectionType_ANON_CLEAR {
+		return false
+	}
+	for _, r := range ac.Resources {
+		switch r.Href {
+		case device.ResourceURI, platform.ResourceURI, resources.ResourceURI:
+		default:
+			return false
+		}
+	}
+	return len(ac.Resources) > 0
+}
+
+// ReplaceACLs sets the access control entries of the device to accessControls. Only the minimal changes
+// computed by acl.Diff are applied, so the entries which are already present are kept with their IDs
+// and the duplicates are removed. The missing entries are added before the others are removed, so the access
+// is not interrupted. The entries set by the ownership transfer are kept, unless WithReplaceOwnerACLs is used.
+func (c *Client) ReplaceACLs(ctx context.Context, deviceID string, accessControls []acl.AccessControl, opts ...ReplaceACLsOption) error {
+	cfg := replaceACLsOptions{
+		commonCommandOptions: applyCommonOptions(),
+	}
+	for _, o := range opts {
+		cfg = o.applyOnReplaceACLs(cfg)
+	}
+	ownerID, err := c.client.GetSdkOwnerID()
+	if err != nil && !cfg.replaceOwnerACLs {
+		return fmt.Errorf("cannot get owner of the client: %w", err)
+	}
+	return c.provisionDeviceWithOptions(ctx, deviceID, cfg.commonCommandOptions, func(ctx context.Context, p *core.ProvisioningClient) error {
+		current, err := p.GetAccessControlList(ctx)
+		if err != nil {
+			return err
+		}
+		desired := accessControls
+		if !cfg.replaceOwnerACLs {
+			desired = slices.Clone(accessControls)
+			for _, ac := range current.AccessControlList {
+				if isOwnerACE(ac, ownerID) {
+					desired = append(desired, ac)
+				}
+			}
+		}
+		remove, add := acl.Diff(current.AccessControlList, desired)
+		if err = p.AddAccessControls(ctx, add...); err != nil {
+			return err
+		}
+		for _, id := range remove {
+			if err = p.DeleteAccessControl(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,83 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/device/v2/schema/acl"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOwnerACE(t *testing.T) {
+	const ownerID = "00000000-0000-0000-0000-000000000001"
+	anonClear := acl.Subject{
+		Subject_Connection: &acl.Subject_Connection{
+			Type: acl.ConnectionType_ANON_CLEAR,
+		},
+	}
+	tests := []struct {
+		name string
+		ac   acl.AccessControl
+		want bool
+	}{
+		{
+			name: "owner",
+			ac: acl.AccessControl{
+				Subject:   acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: ownerID}},
+				Resources: acl.AllResources,
+			},
+			want: true,
+		},
+		{
+			name: "other device",
+			ac: acl.AccessControl{
+				Subject:   acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: "00000000-0000-0000-0000-000000000002"}},
+				Resources: acl.AllResources,
+			},
+		},
+		{
+			name: "anonymous discovery",
+			ac: acl.AccessControl{
+				Subject:   anonClear,
+				Resources: []acl.Resource{{Href: device.ResourceURI}, {Href: resources.ResourceURI}},
+			},
+			want: true,
+		},
+		{
+			name: "anonymous access to resource",
+			ac: acl.AccessControl{
+				Subject:   anonClear,
+				Resources: []acl.Resource{{Href: device.ResourceURI}, {Href: "/light/1"}},
+			},
+		},
+		{
+			name: "role",
+			ac: acl.AccessControl{
+				Subject:   acl.Subject{Subject_Role: &acl.Subject_Role{Role: "admin"}},
+				Resources: acl.AllResources,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isOwnerACE(tt.ac, ownerID))
+		})
+	}
+}
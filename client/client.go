@@ -32,6 +32,8 @@ import (
 	"github.com/plgd-dev/device/v2/pkg/log"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
 	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/introspection"
+	"github.com/plgd-dev/device/v2/schema/sdi"
 	"github.com/plgd-dev/go-coap/v3/net/blockwise"
 	"github.com/plgd-dev/go-coap/v3/options"
 	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
@@ -47,6 +49,12 @@ type ApplicationCallback = interface {
 	GetManufacturerCertificate() (tls.Certificate, error)
 }
 
+// RandomPinApplicationCallback extends ApplicationCallback, it is required by OTMType_RandomPin.
+// GetRandomPin returns the PIN displayed by the device, e.g. entered by the operator.
+type RandomPinApplicationCallback = interface {
+	GetRandomPin(ctx context.Context, deviceID string) (string, error)
+}
+
 type subscription = interface {
 	Cancel()
 	Wait()
@@ -64,6 +72,8 @@ type Config struct {
 
 	UseDeviceIDInQuery bool // if true, deviceID is used also in query. Set this option if you use bridged devices.
 
+	DeviceCacheFile string `yaml:",omitempty"` // if set, the device cache is persisted to the file and restored on start
+
 	// specify one of:
 	DeviceOwnershipSDK     *DeviceOwnershipSDKConfig     `yaml:",omitempty"`
 	DeviceOwnershipBackend *DeviceOwnershipBackendConfig `yaml:",omitempty"`
@@ -173,6 +183,14 @@ func NewClientFromConfig(cfg *Config, app ApplicationCallback, logger core.Logge
 		WithUseDeviceIDInQuery(cfg.UseDeviceIDInQuery),
 	}
 
+	if cfg.DeviceCacheFile != "" {
+		store, err := NewFileDeviceCacheStore(cfg.DeviceCacheFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithDeviceCacheStore(store))
+	}
+
 	deviceOwner, err := NewDeviceOwnerFromConfig(cfg, dialTLS, dialDTLS, app)
 	if err != nil {
 		return nil, err
@@ -196,6 +214,12 @@ type ClientConfig struct {
 	Observer ObserverConfig
 	// UseDeviceIDInQuery if true, deviceID is used also in query. Set this option if you use bridged devices.
 	UseDeviceIDInQuery bool
+	// SecurityDomain is set to every device owned by the client.
+	SecurityDomain *sdi.SDI
+	// GetRoleCertificates returns role certificates asserted to the devices on secure connections.
+	GetRoleCertificates core.GetRoleCertificatesFunc
+	// DeviceCacheStore persists the device cache, the stored devices are loaded by NewClient.
+	DeviceCacheStore DeviceCacheStore
 }
 
 type ClientOptionFunc func(ClientConfig) ClientConfig
@@ -283,6 +307,32 @@ func WithUseDeviceIDInQuery(useDeviceIDInQuery bool) ClientOptionFunc {
 	}
 }
 
+// WithSecurityDomain sets the security domain information to every device owned by the client.
+func WithSecurityDomain(securityDomain sdi.SDI) ClientOptionFunc {
+	return func(cfg ClientConfig) ClientConfig {
+		cfg.SecurityDomain = &securityDomain
+		return cfg
+	}
+}
+
+// WithRoleCertificates sets the PEM encoded role certificates, they are asserted to the devices
+// after the secure connection is established.
+func WithRoleCertificates(getRoleCertificates core.GetRoleCertificatesFunc) ClientOptionFunc {
+	return func(cfg ClientConfig) ClientConfig {
+		cfg.GetRoleCertificates = getRoleCertificates
+		return cfg
+	}
+}
+
+// WithDeviceCacheStore sets the store of the device cache. The stored devices are loaded by NewClient and
+// revalidated on the first use.
+func WithDeviceCacheStore(store DeviceCacheStore) ClientOptionFunc {
+	return func(cfg ClientConfig) ClientConfig {
+		cfg.DeviceCacheStore = store
+		return cfg
+	}
+}
+
 // NewClient constructs a new local client.
 func NewClient(
 	app ApplicationCallback,
@@ -317,6 +367,7 @@ func NewClient(
 	tls := core.TLSConfig{
 		GetCertificate:            deviceOwner.GetIdentityCertificate,
 		GetCertificateAuthorities: deviceOwner.GetIdentityCACerts,
+		GetRoleCertificates:       clientCfg.GetRoleCertificates,
 	}
 	clientCfg.CoreOptions = append(
 		[]core.OptionFunc{
@@ -331,15 +382,26 @@ func NewClient(
 		pollInterval = clientCfg.CacheExpiration / 2
 	}
 	client := Client{
-		client:               oc,
-		app:                  app,
-		deviceCache:          NewDeviceCache(clientCfg.CacheExpiration, pollInterval, coreCfg.Logger),
-		observeResourceCache: coapSync.NewMap[string, *observationsHandler](),
-		deviceOwner:          deviceOwner,
-		subscriptions:        make(map[string]subscription),
-		observerConfig:       clientCfg.Observer,
-		logger:               coreCfg.Logger,
-		useDeviceIDInQuery:   clientCfg.UseDeviceIDInQuery,
+		client:                 oc,
+		app:                    app,
+		deviceCache:            NewDeviceCache(clientCfg.CacheExpiration, pollInterval, coreCfg.Logger),
+		observeResourceCache:   coapSync.NewMap[string, *observationsHandler](),
+		introspectionCache:     coapSync.NewMap[string, *introspection.Document](),
+		introspectionCacheKeys: coapSync.NewMap[string, string](),
+		deviceOwner:            deviceOwner,
+		subscriptions:          make(map[string]subscription),
+		observerConfig:         clientCfg.Observer,
+		logger:                 coreCfg.Logger,
+		useDeviceIDInQuery:     clientCfg.UseDeviceIDInQuery,
+		securityDomain:         clientCfg.SecurityDomain,
+	}
+	if clientCfg.DeviceCacheStore != nil {
+		err := client.deviceCache.Restore(clientCfg.DeviceCacheStore, func(r DeviceCacheRecord) *core.Device {
+			return oc.NewDevice(r.ID, r.DeviceTypes, func() schema.Endpoints { return r.Endpoints }, r.FoundByIP)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot restore device cache: %w", err)
+		}
 	}
 	return &client, nil
 }
@@ -364,6 +426,10 @@ type Client struct {
 	observeResourceCache *coapSync.Map[string, *observationsHandler]
 	observerConfig       ObserverConfig
 
+	introspectionCache *coapSync.Map[string, *introspection.Document]
+	// introspectionCacheKeys maps the deviceID to the key of the cached introspection document.
+	introspectionCacheKeys *coapSync.Map[string, string]
+
 	deviceOwner DeviceOwner
 
 	subscriptionsLock sync.Mutex
@@ -373,6 +439,7 @@ type Client struct {
 	logger              core.Logger
 
 	useDeviceIDInQuery bool
+	securityDomain     *sdi.SDI
 }
 
 func (c *Client) popSubscriptions() map[string]subscription {
@@ -409,6 +476,12 @@ func (c *Client) Close(ctx context.Context) error {
 	for _, s := range c.popSubscriptions() {
 		s.Cancel()
 	}
+	c.observeResourceCache.Range(func(_ string, h *observationsHandler) bool {
+		if h.resilient != nil {
+			h.resilient.stop()
+		}
+		return true
+	})
 	return c.deviceCache.Close(ctx)
 }
 
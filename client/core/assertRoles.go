@@ -0,0 +1,71 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/roles"
+)
+
+func (d *Device) newRolesUpdateRequest(roleCertificates [][]byte) (roles.UpdateRequest, error) {
+	if len(roleCertificates) == 0 {
+		return roles.UpdateRequest{}, errors.New("empty role certificates")
+	}
+	sdkID, err := d.GetSdkOwnerID()
+	if err != nil {
+		return roles.UpdateRequest{}, err
+	}
+	return roles.NewUpdateRequest(sdkID, roleCertificates...), nil
+}
+
+// assertRoles asserts the role certificates of the TLS configuration over the established secure connection.
+func (d *Device) assertRoles(ctx context.Context, cc *coap.ClientCloseHandler) error {
+	if d.cfg.TLSConfig == nil || d.cfg.TLSConfig.GetRoleCertificates == nil {
+		return nil
+	}
+	roleCertificates, err := d.cfg.TLSConfig.GetRoleCertificates()
+	if err != nil {
+		return err
+	}
+	if len(roleCertificates) == 0 {
+		return nil
+	}
+	req, err := d.newRolesUpdateRequest(roleCertificates)
+	if err != nil {
+		return err
+	}
+	return cc.UpdateResource(ctx, roles.ResourceURI, req, nil)
+}
+
+// AssertRoles posts the PEM encoded role certificates to the roles resource of the device. The roles are bound
+// to the identity of the secure connection, so the role based access control entries of the device are applied to it.
+func (d *Device) AssertRoles(ctx context.Context, links schema.ResourceLinks, roleCertificates [][]byte, options ...coap.OptionFunc) error {
+	link, err := GetResourceLink(links, roles.ResourceURI)
+	if err != nil {
+		return err
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	req, err := d.newRolesUpdateRequest(roleCertificates)
+	if err != nil {
+		return MakeInvalidArgument(err)
+	}
+	return d.UpdateResource(ctx, link, req, nil, options...)
+}
@@ -27,6 +27,7 @@ import (
 	pkgError "github.com/plgd-dev/device/v2/pkg/error"
 	"github.com/plgd-dev/device/v2/pkg/log"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
 	coapNet "github.com/plgd-dev/go-coap/v3/net"
 	"github.com/plgd-dev/go-coap/v3/tcp"
 	"github.com/plgd-dev/go-coap/v3/udp"
@@ -85,6 +86,7 @@ type DiscoveryConfiguration struct {
 	MulticastAddressUDP4 []string // default: "[224.0.1.187:5683] (client.DiscoveryAddressUDP4), empty: don't use ipv4 multicast"
 	MulticastAddressUDP6 []string // default: "[ff02::158]:5683", "[ff03::158]:5683", "[ff05::158]:5683]"] (client.DiscoveryAddressUDP6), empty: don't use ipv6 multicast"
 	MulticastOptions     []coapNet.MulticastOption
+	ResourceDirectories  []ResourceDirectory // default: empty, the devices are looked up also in the resource directories
 }
 
 func WithLogger(logger Logger) OptionFunc {
@@ -150,6 +152,13 @@ func (c *Client) getDeviceConfiguration() DeviceConfiguration {
 	}
 }
 
+// NewDevice creates the device with the configuration of the client, e.g. to restore the device from the persisted cache.
+func (c *Client) NewDevice(deviceID string, deviceTypes []string, getEndpoints func() schema.Endpoints, foundByIP string) *Device {
+	d := NewDevice(c.getDeviceConfiguration(), deviceID, deviceTypes, getEndpoints)
+	d.setFoundByIP(foundByIP)
+	return d
+}
+
 func DefaultDiscoveryConfiguration() DiscoveryConfiguration {
 	return DiscoveryConfiguration{
 		MulticastHopLimit:    0, // will be set to 1 or 255 based on address
@@ -94,10 +94,15 @@ type GetCertificateFunc func() (tls.Certificate, error)
 // GetCertificateAuthoritiesFunc returns certificate authorities to verify peers
 type GetCertificateAuthoritiesFunc func() ([]*x509.Certificate, error)
 
+// GetRoleCertificatesFunc returns PEM encoded role certificates asserted to the peers
+type GetRoleCertificatesFunc func() ([][]byte, error)
+
 type TLSConfig struct {
 	// User for communication with owned devices and cloud
 	GetCertificate            GetCertificateFunc
 	GetCertificateAuthorities GetCertificateAuthoritiesFunc
+	// GetRoleCertificates is optional, the role certificates are asserted to the device after the secure connection is established.
+	GetRoleCertificates GetRoleCertificatesFunc
 }
 
 type conn struct {
@@ -267,17 +272,28 @@ func (d *Device) loadORCreate(addr string) (c *conn) {
 }
 
 func (d *Device) dial(ctx context.Context, addr net.Addr) (*coap.ClientCloseHandler, error) {
+	var cc *coap.ClientCloseHandler
+	var err error
 	switch schema.Scheme(addr.GetScheme()) {
 	case schema.UDPScheme:
 		return d.cfg.DialUDP(ctx, addr.String())
 	case schema.UDPSecureScheme:
-		return d.dialDTLS(ctx, addr.String(), d.cfg.TLSConfig, coap.VerifyIdentityCertificate)
+		cc, err = d.dialDTLS(ctx, addr.String(), d.cfg.TLSConfig, coap.VerifyIdentityCertificate)
 	case schema.TCPScheme:
 		return d.cfg.DialTCP(ctx, addr.String())
 	case schema.TCPSecureScheme:
-		return d.dialTLS(ctx, addr.String(), d.cfg.TLSConfig, coap.VerifyIdentityCertificate)
+		cc, err = d.dialTLS(ctx, addr.String(), d.cfg.TLSConfig, coap.VerifyIdentityCertificate)
+	default:
+		return nil, fmt.Errorf("unknown scheme :%v", addr.GetScheme())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if errA := d.assertRoles(ctx, cc); errA != nil {
+		// the connection is still usable with the identity certificate
+		d.cfg.Logger.Debug(fmt.Errorf("cannot assert roles to device %v: %w", d.DeviceID(), errA).Error())
 	}
-	return nil, fmt.Errorf("unknown scheme :%v", addr.GetScheme())
+	return cc, nil
 }
 
 func (d *Device) removeConn(addr string, cc *conn) {
@@ -138,8 +138,15 @@ func (c *Client) GetDeviceByMulticast(ctx context.Context, deviceID string, disc
 	}()
 
 	h := newDevicesHandler(c.getDeviceConfiguration(), deviceID, cancel)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.LookupResourceDirectories(findCtx, discoveryConfiguration, h.handleLinks, h.Error, coap.WithResourceType(device.ResourceType), coap.WithDeviceID(deviceID))
+	}()
 	// we want to just get "oic.wk.d" resource, because links will be get via unicast to /oic/res
 	err = DiscoverDevices(findCtx, multicastConn, h, coap.WithResourceType(device.ResourceType), coap.WithDeviceID(deviceID))
+	wg.Wait()
 	if err != nil {
 		return nil, MakeDataLoss(fmt.Errorf("could not get the device %s: %w", deviceID, err))
 	}
@@ -191,6 +198,10 @@ func (h *deviceHandler) Handle(_ context.Context, conn *client.Conn, links schem
 	if errC := conn.Close(); errC != nil {
 		h.deviceCfg.Logger.Debug(fmt.Errorf("device handler cannot close connection: %w", errC).Error())
 	}
+	h.handleLinks(links)
+}
+
+func (h *deviceHandler) handleLinks(links schema.ResourceLinks) {
 	h.lock.Lock()
 	defer h.lock.Unlock()
 	links = links.GetResourceLinks(device.ResourceType)
@@ -58,8 +58,18 @@ func (c *Client) GetDevicesByMulticast(ctx context.Context, discoveryConfigurati
 			}
 		}
 	}()
+	h := newDiscoveryHandler(c.getDeviceConfiguration(), handler)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.LookupResourceDirectories(ctx, discoveryConfiguration, func(links schema.ResourceLinks) {
+			h.handleLinks(ctx, links)
+		}, handler.Error, coap.WithResourceType(device.ResourceType))
+	}()
+	defer wg.Wait()
 	// we want to just get "oic.wk.d" resource, because links will be get via unicast to /oic/res
-	return DiscoverDevices(ctx, multicastConn, newDiscoveryHandler(c.getDeviceConfiguration(), handler), coap.WithResourceType(device.ResourceType))
+	return DiscoverDevices(ctx, multicastConn, h, coap.WithResourceType(device.ResourceType))
 }
 
 func newDiscoveryHandler(
@@ -82,6 +92,10 @@ func (h *discoveryHandler) Handle(ctx context.Context, conn *client.Conn, links
 	if errC := conn.Close(); errC != nil {
 		h.handler.Error(fmt.Errorf("discovery handler cannot close connection: %w", errC))
 	}
+	h.handleLinks(ctx, links)
+}
+
+func (h *discoveryHandler) handleLinks(ctx context.Context, links schema.ResourceLinks) {
 	deviceLinks := make(map[string]schema.ResourceLinks)
 	for _, link := range links {
 		deviceID := link.GetDeviceID()
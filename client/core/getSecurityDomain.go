@@ -0,0 +1,38 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/sdi"
+)
+
+// GetSecurityDomain gets device's security domain information resource.
+func (d *Device) GetSecurityDomain(ctx context.Context, links schema.ResourceLinks, options ...coap.OptionFunc) (sdi.SDI, error) {
+	link, ok := links.GetResourceLink(sdi.ResourceURI)
+	if !ok {
+		return sdi.SDI{}, fmt.Errorf("cannot find %v in links: %+v", sdi.ResourceURI, links)
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	var v sdi.SDI
+	err := d.GetResource(ctx, link, &v, options...)
+	return v, err
+}
@@ -38,6 +38,21 @@ type Client interface {
 	Dial(ctx context.Context, addr kitNet.Addr) (*coap.ClientCloseHandler, error)
 }
 
+// DeviceClient is implemented by clients which need the deviceID to establish the connection,
+// e.g. the random PIN method uses it as the salt for the preshared key derivation.
+type DeviceClient interface {
+	Client
+	DialDevice(ctx context.Context, deviceID string, addr kitNet.Addr) (*coap.ClientCloseHandler, error)
+}
+
+// Dial connects to the device by the client. DialDevice is used when the client implements DeviceClient.
+func Dial(ctx context.Context, c Client, deviceID string, addr kitNet.Addr) (*coap.ClientCloseHandler, error) {
+	if dc, ok := c.(DeviceClient); ok {
+		return dc.DialDevice(ctx, deviceID, addr)
+	}
+	return c.Dial(ctx, addr)
+}
+
 func encodeToPem(encoding csr.CertificateEncoding, data []byte) []byte {
 	if encoding == csr.CertificateEncoding_DER {
 		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: data})
@@ -0,0 +1,133 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package randompin
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pion/dtls/v3"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/go-coap/v3/udp"
+	kitNet "github.com/plgd-dev/kit/v2/net"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// DefaultIterations is the PBKDF2 iteration count defined by the OCF security specification.
+	DefaultIterations = 1000
+	// PSKIdentity is the identity used by the onboarding tool for the random PIN ownership transfer method.
+	PSKIdentity = "oic.sec.doxm.rdp"
+
+	pskLength = 16
+)
+
+// PinFunc returns the PIN which is displayed by the device identified by deviceID.
+type PinFunc = func(ctx context.Context, deviceID string) (string, error)
+
+type DialDTLS = func(ctx context.Context, addr string, dtlsCfg *dtls.Config, opts ...udp.Option) (*coap.ClientCloseHandler, error)
+
+type Client struct {
+	getPin     PinFunc
+	iterations int
+	dialDTLS   DialDTLS
+}
+
+type OptionFunc func(Client) Client
+
+func WithDialDTLS(dial DialDTLS) OptionFunc {
+	return func(cfg Client) Client {
+		if dial != nil {
+			cfg.dialDTLS = dial
+		}
+		return cfg
+	}
+}
+
+// WithIterations overrides the PBKDF2 iteration count used to derive the preshared key from the PIN.
+func WithIterations(iterations int) OptionFunc {
+	return func(cfg Client) Client {
+		if iterations > 0 {
+			cfg.iterations = iterations
+		}
+		return cfg
+	}
+}
+
+func NewClient(getPin PinFunc, opts ...OptionFunc) *Client {
+	c := Client{
+		getPin:     getPin,
+		iterations: DefaultIterations,
+		dialDTLS:   coap.DialUDPSecure,
+	}
+	for _, o := range opts {
+		c = o(c)
+	}
+	return &c
+}
+
+// DerivePSK derives the preshared key from the PIN. The UUID of the device is used as the salt.
+func DerivePSK(pin []byte, deviceID string, iterations int) ([]byte, error) {
+	if len(pin) == 0 {
+		return nil, errors.New("invalid pin")
+	}
+	id, err := uuid.Parse(deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deviceID %v: %w", deviceID, err)
+	}
+	salt, _ := id.MarshalBinary()
+	return pbkdf2.Key(pin, salt, iterations, pskLength, sha256.New), nil
+}
+
+func (*Client) Type() doxm.OwnerTransferMethod {
+	return doxm.SharedPin
+}
+
+// Dial is not supported because the preshared key is derived from the deviceID, use DialDevice instead.
+func (*Client) Dial(_ context.Context, addr kitNet.Addr) (*coap.ClientCloseHandler, error) {
+	return nil, fmt.Errorf("cannot dial to url %v: deviceID is required to derive preshared key", addr.URL())
+}
+
+func (c *Client) DialDevice(ctx context.Context, deviceID string, addr kitNet.Addr) (*coap.ClientCloseHandler, error) {
+	if schema.Scheme(addr.GetScheme()) != schema.UDPSecureScheme {
+		return nil, fmt.Errorf("cannot dial to url %v: scheme %v not supported", addr.URL(), addr.GetScheme())
+	}
+	if c.getPin == nil {
+		return nil, errors.New("cannot get pin: callback is not set")
+	}
+	pin, err := c.getPin(ctx, deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pin: %w", err)
+	}
+	psk, err := DerivePSK([]byte(pin), deviceID, c.iterations)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive preshared key: %w", err)
+	}
+	dtlsConfig := dtls.Config{
+		PSKIdentityHint: []byte(PSKIdentity),
+		PSK: func([]byte) ([]byte, error) {
+			return psk, nil
+		},
+		CipherSuites: []dtls.CipherSuiteID{dtls.TLS_ECDHE_PSK_WITH_AES_128_CBC_SHA256},
+	}
+	return c.dialDTLS(ctx, addr.String(), &dtlsConfig)
+}
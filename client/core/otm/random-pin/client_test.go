@@ -0,0 +1,60 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package randompin_test
+
+import (
+	"context"
+	"testing"
+
+	randompin "github.com/plgd-dev/device/v2/client/core/otm/random-pin"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	kitNet "github.com/plgd-dev/kit/v2/net"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerivePSK(t *testing.T) {
+	deviceID := "00000000-0000-0000-0000-000000000001"
+	psk, err := randompin.DerivePSK([]byte("12345678"), deviceID, randompin.DefaultIterations)
+	require.NoError(t, err)
+	require.Len(t, psk, 16)
+
+	psk2, err := randompin.DerivePSK([]byte("12345678"), deviceID, randompin.DefaultIterations)
+	require.NoError(t, err)
+	require.Equal(t, psk, psk2)
+
+	other, err := randompin.DerivePSK([]byte("87654321"), deviceID, randompin.DefaultIterations)
+	require.NoError(t, err)
+	require.NotEqual(t, psk, other)
+
+	_, err = randompin.DerivePSK(nil, deviceID, randompin.DefaultIterations)
+	require.Error(t, err)
+	_, err = randompin.DerivePSK([]byte("12345678"), "invalid", randompin.DefaultIterations)
+	require.Error(t, err)
+}
+
+func TestClientDial(t *testing.T) {
+	c := randompin.NewClient(func(context.Context, string) (string, error) {
+		return "12345678", nil
+	})
+	require.Equal(t, doxm.SharedPin, c.Type())
+
+	_, err := c.Dial(context.Background(), kitNet.MakeAddr("coaps", "127.0.0.1", 5684))
+	require.Error(t, err)
+
+	_, err = c.DialDevice(context.Background(), "00000000-0000-0000-0000-000000000001", kitNet.MakeAddr("coaps+tcp", "127.0.0.1", 5684))
+	require.Error(t, err)
+}
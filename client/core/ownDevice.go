@@ -54,6 +54,8 @@ type ownCfg struct {
 	sign            otm.SignFunc
 	actionDuringOwn ActionDuringOwnFunc
 	actionAfterOwn  ActionAfterOwnFunc
+	securityProfile string
+	securityDomain  *sdi.SDI
 }
 
 type OwnOption = func(ownCfg) ownCfg
@@ -92,6 +94,22 @@ func WithSetupCertificates(sign otm.SignFunc) OwnOption {
 	}
 }
 
+// WithSecurityProfile switches the device to the security profile (see sp.Profile_*) before it is moved to the normal operation.
+func WithSecurityProfile(profile string) OwnOption {
+	return func(o ownCfg) ownCfg {
+		o.securityProfile = profile
+		return o
+	}
+}
+
+// WithSecurityDomain sets the security domain information of the device before it is moved to the normal operation.
+func WithSecurityDomain(securityDomain sdi.SDI) OwnOption {
+	return func(o ownCfg) ownCfg {
+		o.securityDomain = &securityDomain
+		return o
+	}
+}
+
 type connUpdateResourcer interface {
 	UpdateResource(context.Context, string, interface{}, interface{}, ...coap.OptionFunc) error
 	DeleteResource(context.Context, string, interface{}, ...coap.OptionFunc) error
@@ -248,6 +266,33 @@ func setACL(ctx context.Context, conn connUpdateResourcer, links schema.Resource
 	return conn.UpdateResource(ctx, link.Href, setACL, nil)
 }
 
+func setSecurityProfile(ctx context.Context, conn connUpdateResourcer, links schema.ResourceLinks, profile string) error {
+	link, err := GetResourceLink(links, sp.ResourceURI)
+	if err != nil {
+		return err
+	}
+	return conn.UpdateResource(ctx, link.Href, sp.SecurityProfileUpdateRequest{
+		CurrentProfile: profile,
+	}, nil)
+}
+
+// validateSecurityDomain checks the security domain before it is set to the device.
+func validateSecurityDomain(securityDomain sdi.SDI) error {
+	if _, err := uuid.Parse(securityDomain.UUID); err != nil {
+		return fmt.Errorf("invalid uuid: %w", err)
+	}
+	return nil
+}
+
+// setSecurityDomain sets the security domain information of the device during the ownership transfer.
+func setSecurityDomain(ctx context.Context, conn connUpdateResourcer, links schema.ResourceLinks, securityDomain sdi.SDI) error {
+	link, err := GetResourceLink(links, sdi.ResourceURI)
+	if err != nil {
+		return err
+	}
+	return conn.UpdateResource(ctx, link.Href, securityDomain, nil)
+}
+
 // findOTMClient finds supported client in order as user wants. The first match will be used.
 func findOTMClient(otmClients []otm.Client, deviceSupportedOwnerTransferMethods []doxm.OwnerTransferMethod) otm.Client {
 	for _, c := range otmClients {
@@ -281,18 +326,18 @@ func (d *Device) setDoxmDeviceID(ctx context.Context, cc *coap.ClientCloseHandle
 	return deviceID, nil
 }
 
-func getTLSClient(ctx context.Context, links schema.ResourceLinks, otmClient otm.Client) (*coap.ClientCloseHandler, kitNet.Addr, error) {
+func getTLSClient(ctx context.Context, deviceID string, links schema.ResourceLinks, otmClient otm.Client) (*coap.ClientCloseHandler, kitNet.Addr, error) {
 	var errs *multierror.Error
 	for _, link := range links {
 		if addr, err := link.GetUDPSecureAddr(); err == nil {
-			tlsClient, err := otmClient.Dial(ctx, addr)
+			tlsClient, err := otm.Dial(ctx, otmClient, deviceID, addr)
 			if err == nil {
 				return tlsClient, addr, nil
 			}
 			errs = multierror.Append(errs, fmt.Errorf("cannot connect to %v: %w", addr.URL(), err))
 		}
 		if addr, err := link.GetTCPSecureAddr(); err == nil {
-			tlsClient, err := otmClient.Dial(ctx, addr)
+			tlsClient, err := otm.Dial(ctx, otmClient, deviceID, addr)
 			if err == nil {
 				return tlsClient, addr, nil
 			}
@@ -493,12 +538,14 @@ func getDTLSClient(ctx context.Context, psk []byte, sdkID, addr string, oc otm.C
 }
 
 type deviceConfigurer struct {
-	tlsClient      *coap.ClientCloseHandler
-	otmClient      otm.Client
-	ownerID        string
-	address        string
-	actionAfterOwn ActionAfterOwnFunc
-	err            func(error)
+	tlsClient       *coap.ClientCloseHandler
+	otmClient       otm.Client
+	ownerID         string
+	address         string
+	actionAfterOwn  ActionAfterOwnFunc
+	securityProfile string
+	securityDomain  *sdi.SDI
+	err             func(error)
 }
 
 func (d deviceConfigurer) configure(ctx context.Context, links schema.ResourceLinks, psk []byte) error {
@@ -528,6 +575,20 @@ func (d deviceConfigurer) configure(ctx context.Context, links schema.ResourceLi
 		return MakeInternal(errorf("cannot update resource acl: %w", err))
 	}
 
+	if d.securityProfile != "" {
+		err = setSecurityProfile(ctx, pskConn, links, d.securityProfile)
+		if err != nil {
+			return MakeInternal(errorf("cannot update security profile: %w", err))
+		}
+	}
+
+	if d.securityDomain != nil {
+		err = setSecurityDomain(ctx, pskConn, links, *d.securityDomain)
+		if err != nil {
+			return MakeInternal(errorf("cannot update security domain: %w", err))
+		}
+	}
+
 	// Provision the device to switch back to normal operation.
 	err = updateOperationalState(ctx, pskConn, pstat.OperationalState_RFNOP)
 	if err != nil {
@@ -558,6 +619,11 @@ func (d *Device) Own(
 	for _, opt := range options {
 		cfg = opt(cfg)
 	}
+	if cfg.securityDomain != nil {
+		if err := validateSecurityDomain(*cfg.securityDomain); err != nil {
+			return MakeInvalidArgument(fmt.Errorf("cannot set security domain: %w", err))
+		}
+	}
 
 	ownership, err := d.GetOwnership(ctx, links)
 	if err != nil {
@@ -589,7 +655,7 @@ func (d *Device) Own(
 		return MakeInternal(errorf("cannot select otm: %w", err))
 	}
 
-	tlsClient, tlsAddr, err := getTLSClient(ctx, links, otmClient)
+	tlsClient, tlsAddr, err := getTLSClient(ctx, d.DeviceID(), links, otmClient)
 	if err != nil {
 		return MakeInternal(errorf("cannot get udp/tcp secure address: %v", err))
 	}
@@ -606,11 +672,13 @@ func (d *Device) Own(
 	}
 
 	dc := deviceConfigurer{
-		tlsClient:      tlsClient,
-		otmClient:      otmClient,
-		ownerID:        sdkID,
-		address:        tlsAddr.String(),
-		actionAfterOwn: cfg.actionAfterOwn,
+		tlsClient:       tlsClient,
+		otmClient:       otmClient,
+		ownerID:         sdkID,
+		address:         tlsAddr.String(),
+		actionAfterOwn:  cfg.actionAfterOwn,
+		securityProfile: cfg.securityProfile,
+		securityDomain:  cfg.securityDomain,
 		err: func(err error) {
 			d.cfg.Logger.Debug(err.Error())
 		},
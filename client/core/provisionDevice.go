@@ -22,6 +22,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
 	"github.com/plgd-dev/device/v2/schema"
@@ -29,6 +30,8 @@ import (
 	"github.com/plgd-dev/device/v2/schema/cloud"
 	"github.com/plgd-dev/device/v2/schema/credential"
 	"github.com/plgd-dev/device/v2/schema/pstat"
+	"github.com/plgd-dev/device/v2/schema/sdi"
+	"github.com/plgd-dev/device/v2/schema/sp"
 	"github.com/plgd-dev/kit/v2/strings"
 )
 
@@ -105,6 +108,41 @@ func (c *ProvisioningClient) AddCredentials(ctx context.Context, cr credential.C
 	return nil
 }
 
+// GetCredentials retrieves the cred resource.
+func (c *ProvisioningClient) GetCredentials(ctx context.Context) (credential.CredentialResponse, error) {
+	const errMsg = "could not get credentials of the device: %w"
+	link, err := GetResourceLink(c.links, credential.ResourceURI)
+	if err != nil {
+		return credential.CredentialResponse{}, fmt.Errorf(errMsg, err)
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	var resp credential.CredentialResponse
+	err = c.GetResource(ctx, link, &resp, c.options...)
+	if err != nil {
+		return credential.CredentialResponse{}, fmt.Errorf(errMsg, err)
+	}
+	return resp, nil
+}
+
+// DeleteCredential removes the credential with the ID from the cred resource.
+func (c *ProvisioningClient) DeleteCredential(ctx context.Context, id int) error {
+	errMsg := "could not delete credential " + strconv.Itoa(id) + " of the device: %w"
+	if id <= 0 {
+		return fmt.Errorf(errMsg, errors.New("invalid ID"))
+	}
+	link, err := GetResourceLink(c.links, credential.ResourceURI)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	options := append([]coap.OptionFunc{coap.WithQuery("credid=" + strconv.Itoa(id))}, c.options...)
+	err = c.DeleteResource(ctx, link, nil, options...)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	return nil
+}
+
 func (c *ProvisioningClient) AddCertificateAuthority(ctx context.Context, subject string, cert *x509.Certificate) error {
 	setCaCredential := credential.CredentialUpdateRequest{
 		Credentials: []credential.Credential{
@@ -150,6 +188,121 @@ func (c *ProvisioningClient) SetCloudResource(ctx context.Context, r cloud.Confi
 	return nil
 }
 
+// GetSecurityProfile retrieves the sp resource.
+func (c *ProvisioningClient) GetSecurityProfile(ctx context.Context) (sp.SecurityProfile, error) {
+	const errMsg = "could not get security profile of the device: %w"
+	link, err := GetResourceLink(c.links, sp.ResourceURI)
+	if err != nil {
+		return sp.SecurityProfile{}, fmt.Errorf(errMsg, err)
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	var resp sp.SecurityProfile
+	err = c.GetResource(ctx, link, &resp, c.options...)
+	if err != nil {
+		return sp.SecurityProfile{}, fmt.Errorf(errMsg, err)
+	}
+	return resp, nil
+}
+
+// SetSecurityProfile switches the current security profile of the device.
+func (c *ProvisioningClient) SetSecurityProfile(ctx context.Context, profile string) error {
+	const errMsg = "could not set security profile of the device: %w"
+	if profile == "" {
+		return fmt.Errorf(errMsg, errors.New("invalid profile"))
+	}
+	link, err := GetResourceLink(c.links, sp.ResourceURI)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	err = c.UpdateResource(ctx, link, sp.SecurityProfileUpdateRequest{
+		CurrentProfile: profile,
+	}, nil, c.options...)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	return nil
+}
+
+// SetSecurityDomain sets the security domain information of the device.
+func (c *ProvisioningClient) SetSecurityDomain(ctx context.Context, securityDomain sdi.SDI) error {
+	const errMsg = "could not set security domain of the device: %w"
+	if err := validateSecurityDomain(securityDomain); err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	link, err := GetResourceLink(c.links, sdi.ResourceURI)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	err = c.UpdateResource(ctx, link, securityDomain, nil, c.options...)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	return nil
+}
+
+func (c *ProvisioningClient) getACLLink() (schema.ResourceLink, error) {
+	link, err := GetResourceLink(c.links, acl.ResourceURI)
+	if err != nil {
+		return schema.ResourceLink{}, err
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	return link, nil
+}
+
+// GetAccessControlList retrieves the acl resource.
+func (c *ProvisioningClient) GetAccessControlList(ctx context.Context) (acl.Response, error) {
+	const errMsg = "could not get ACL of the device: %w"
+	link, err := c.getACLLink()
+	if err != nil {
+		return acl.Response{}, fmt.Errorf(errMsg, err)
+	}
+	var resp acl.Response
+	err = c.GetResource(ctx, link, &resp, c.options...)
+	if err != nil {
+		return acl.Response{}, fmt.Errorf(errMsg, err)
+	}
+	return resp, nil
+}
+
+// AddAccessControls appends the access controls to the acl resource, IDs are assigned by the device.
+func (c *ProvisioningClient) AddAccessControls(ctx context.Context, accessControls ...acl.AccessControl) error {
+	if len(accessControls) == 0 {
+		return nil
+	}
+	const errMsg = "could not add ACL to the device: %w"
+	link, err := c.getACLLink()
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	err = c.UpdateResource(ctx, link, acl.UpdateRequest{
+		AccessControlList: accessControls,
+	}, nil, c.options...)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	return nil
+}
+
+// DeleteAccessControl removes the access control with the ID from the acl resource.
+func (c *ProvisioningClient) DeleteAccessControl(ctx context.Context, id int) error {
+	errMsg := "could not delete ACE " + strconv.Itoa(id) + " of the device: %w"
+	if id <= 0 {
+		return fmt.Errorf(errMsg, errors.New("invalid ID"))
+	}
+	link, err := c.getACLLink()
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	options := append([]coap.OptionFunc{coap.WithQuery("aceid=" + strconv.Itoa(id))}, c.options...)
+	err = c.DeleteResource(ctx, link, nil, options...)
+	if err != nil {
+		return fmt.Errorf(errMsg, err)
+	}
+	return nil
+}
+
 // SetAccessControl updates the acl resource.
 // Usage: SetAccessControl(ctx, schema.AllPermissions, schema.TLSConnection, schema.AllResources)
 func (c *ProvisioningClient) SetAccessControl(
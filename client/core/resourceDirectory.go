@@ -0,0 +1,82 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+)
+
+// ResourceDirectoryHref is the default href of the resource directory lookup.
+const ResourceDirectoryHref = "/oic/rd"
+
+// ResourceDirectory is a CoAP resource directory used to discover devices which are not reachable by multicast,
+// e.g. devices in routed networks of the same site.
+type ResourceDirectory struct {
+	// URI of the resource directory, e.g. "coap+tcp://rd.example.com:5683". For the secure schemes the resource
+	// directory must present a certificate trusted by the client.
+	URI string
+	// Href of the lookup, empty means ResourceDirectoryHref.
+	Href string
+}
+
+// LookupResourceDirectory gets the links of the devices published to the resource directory.
+func (c *Client) LookupResourceDirectory(ctx context.Context, rd ResourceDirectory, options ...coap.OptionFunc) (schema.ResourceLinks, error) {
+	href := rd.Href
+	if href == "" {
+		href = ResourceDirectoryHref
+	}
+	// the resource directory is accessed like a device, the connection is closed after the lookup
+	d := NewDevice(c.getDeviceConfiguration(), "", nil, func() schema.Endpoints { return nil })
+	defer func() {
+		if errC := d.Close(ctx); errC != nil {
+			c.logger.Debug(fmt.Errorf("lookup resource directory %v error: cannot close connection: %w", rd.URI, errC).Error())
+		}
+	}()
+	_, cc, err := d.connectToEndpoints(ctx, schema.Endpoints{{URI: rd.URI}})
+	if err != nil {
+		return nil, MakeUnavailable(fmt.Errorf("cannot lookup resource directory %v: %w", rd.URI, err))
+	}
+	var links schema.ResourceLinks
+	if err = cc.GetResourceWithCodec(ctx, href, DiscoverDeviceCodec{}, &links, options...); err != nil {
+		return nil, MakeDataLoss(fmt.Errorf("cannot lookup resource directory %v%v: %w", rd.URI, href, err))
+	}
+	return links, nil
+}
+
+// LookupResourceDirectories looks up all resource directories of the discovery configuration concurrently
+// and sends the links to the handle function. It returns when all lookups are done.
+func (c *Client) LookupResourceDirectories(ctx context.Context, cfg DiscoveryConfiguration, handle func(links schema.ResourceLinks), errFn func(error), options ...coap.OptionFunc) {
+	var wg sync.WaitGroup
+	wg.Add(len(cfg.ResourceDirectories))
+	for _, rd := range cfg.ResourceDirectories {
+		go func() {
+			defer wg.Done()
+			links, err := c.LookupResourceDirectory(ctx, rd, options...)
+			if err != nil {
+				errFn(err)
+				return
+			}
+			handle(links)
+		}()
+	}
+	wg.Wait()
+}
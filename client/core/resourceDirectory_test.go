@@ -0,0 +1,128 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/mux"
+	coapNet "github.com/plgd-dev/go-coap/v3/net"
+	"github.com/plgd-dev/go-coap/v3/options"
+	"github.com/plgd-dev/go-coap/v3/tcp"
+	"github.com/stretchr/testify/require"
+)
+
+const testRDDeviceID = "00000000-0000-0000-0000-000000000001"
+
+func newTestResourceDirectory(t *testing.T) (string, func() []string) {
+	links := schema.ResourceLinks{
+		{
+			Href:          device.ResourceURI,
+			ResourceTypes: []string{device.ResourceType, "oic.d.light"},
+			Anchor:        "ocf://" + testRDDeviceID,
+			DeviceID:      testRDDeviceID,
+			Endpoints:     schema.Endpoints{{URI: "coap+tcp://10.0.0.1:5683"}},
+		},
+	}
+	data, err := cbor.Encode(links)
+	require.NoError(t, err)
+	var lock sync.Mutex
+	var queries []string
+	m := mux.NewRouter()
+	err = m.Handle(core.ResourceDirectoryHref, mux.HandlerFunc(func(w mux.ResponseWriter, r *mux.Message) {
+		q, _ := r.Options().Queries()
+		lock.Lock()
+		queries = append(queries, q...)
+		lock.Unlock()
+		errS := w.SetResponse(codes.Content, message.AppOcfCbor, bytes.NewReader(data))
+		require.NoError(t, errS)
+	}))
+	require.NoError(t, err)
+	l, err := coapNet.NewTCPListener("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := tcp.NewServer(options.WithMux(m))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = s.Serve(l)
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+	return "coap+tcp://" + l.Addr().String(), func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), queries...)
+	}
+}
+
+type testRDDevicesHandler struct {
+	lock    sync.Mutex
+	devices []*core.Device
+}
+
+func (h *testRDDevicesHandler) Handle(_ context.Context, d *core.Device) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.devices = append(h.devices, d)
+}
+
+func (h *testRDDevicesHandler) Error(error) {}
+
+func TestResourceDirectory(t *testing.T) {
+	uri, getQueries := newTestResourceDirectory(t)
+	c := core.NewClient()
+	cfg := core.DiscoveryConfiguration{
+		ResourceDirectories: []core.ResourceDirectory{{URI: uri}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	links, err := c.LookupResourceDirectory(ctx, core.ResourceDirectory{URI: uri})
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+
+	_, err = c.LookupResourceDirectory(ctx, core.ResourceDirectory{URI: uri, Href: "/unknown"})
+	require.Error(t, err)
+
+	d, err := c.GetDeviceByMulticast(ctx, testRDDeviceID, cfg)
+	require.NoError(t, err)
+	require.Equal(t, testRDDeviceID, d.DeviceID())
+	require.Equal(t, schema.Endpoints{{URI: "coap+tcp://10.0.0.1:5683"}}, d.GetEndpoints())
+	require.Contains(t, getQueries(), "di="+testRDDeviceID)
+
+	devicesCtx, devicesCancel := context.WithTimeout(context.Background(), time.Millisecond*500)
+	defer devicesCancel()
+	var h testRDDevicesHandler
+	err = c.GetDevicesByMulticast(devicesCtx, cfg, &h)
+	require.NoError(t, err)
+	require.Len(t, h.devices, 1)
+	require.Equal(t, testRDDeviceID, h.devices[0].DeviceID())
+	require.Contains(t, getQueries(), "rt="+device.ResourceType)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/plgd-dev/device/v2/client/core"
 	codecOcf "github.com/plgd-dev/device/v2/pkg/codec/ocf"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema/introspection"
 )
 
 // CreateResource creates the resource from the device.
@@ -54,5 +55,14 @@ func (c *Client) CreateResource(
 		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
 	}
 
+	if cfg.validatePayload {
+		err = c.validatePayload(ctx, deviceID, cfg.discoveryConfiguration, func(doc *introspection.Document) error {
+			return doc.ValidateCreateRequest(link.Href, request)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return device.UpdateResourceWithCodec(ctx, link, cfg.codec, request, response, cfg.opts...)
 }
@@ -0,0 +1,139 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema/credential"
+)
+
+// DeviceCredential is a credential of the cred resource with the decoded certificates of the public data.
+type DeviceCredential struct {
+	credential.Credential
+	// Certificates are parsed from the public data when the credential contains certificates.
+	Certificates []*x509.Certificate
+	// CertificatesError is set when the public data cannot be decoded.
+	CertificatesError error
+}
+
+func toDeviceCredentials(creds []credential.Credential) []DeviceCredential {
+	result := make([]DeviceCredential, 0, len(creds))
+	for _, cred := range creds {
+		dc := DeviceCredential{
+			Credential: cred,
+		}
+		if cred.Type == credential.CredentialType_ASYMMETRIC_SIGNING_WITH_CERTIFICATE && cred.PublicData != nil {
+			dc.Certificates, dc.CertificatesError = cred.PublicData.Certificates()
+		}
+		result = append(result, dc)
+	}
+	return result
+}
+
+// GetCredentials returns the credentials of the device.
+func (c *Client) GetCredentials(ctx context.Context, deviceID string, opts ...CommonCommandOption) ([]DeviceCredential, error) {
+	cfg := applyCommonOptions(opts...)
+	d, links, err := c.GetDevice(ctx, deviceID, WithDiscoveryConfiguration(cfg.discoveryConfiguration))
+	if err != nil {
+		return nil, err
+	}
+	link, err := core.GetResourceLink(links, credential.ResourceURI)
+	if err != nil {
+		return nil, err
+	}
+	link.Endpoints = link.GetSecureEndpoints()
+	if c.useDeviceIDInQuery {
+		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
+	}
+	var resp credential.CredentialResponse
+	err = d.GetResource(ctx, link, &resp, cfg.opts...)
+	if err != nil {
+		return nil, err
+	}
+	return toDeviceCredentials(resp.Credentials), nil
+}
+
+// DeleteCredentials removes the credentials with the IDs from the device.
+func (c *Client) DeleteCredentials(ctx context.Context, deviceID string, ids ...int) error {
+	if len(ids) == 0 {
+		return core.MakeInvalidArgument(errors.New("empty credential IDs"))
+	}
+	return c.provisionDevice(ctx, deviceID, nil, func(ctx context.Context, p *core.ProvisioningClient) error {
+		for _, id := range ids {
+			if err := p.DeleteCredential(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if bytes.Equal(c.Raw, cert.Raw) {
+			return true
+		}
+	}
+	return false
+}
+
+// RotateCertificateAuthority adds newCA as a trust anchor of the subject, when the device doesn't have it yet, and
+// returns the IDs of the previous trust anchors of the subject. The previous trust anchors are kept, because
+// the identity certificates of the device and of the client can be still signed by them. Remove them by
+// DeleteCredentials after the identity certificates are reissued by newCA, then the rotation is finished.
+func (c *Client) RotateCertificateAuthority(ctx context.Context, deviceID string, subject string, newCA *x509.Certificate, opts ...CommonCommandOption) ([]int, error) {
+	if newCA == nil {
+		return nil, core.MakeInvalidArgument(errors.New("invalid certificate authority"))
+	}
+	var previous []int
+	err := c.provisionDevice(ctx, deviceID, opts, func(ctx context.Context, p *core.ProvisioningClient) error {
+		resp, err := p.GetCredentials(ctx)
+		if err != nil {
+			return err
+		}
+		var present bool
+		previous = nil
+		for _, cred := range toDeviceCredentials(resp.Credentials) {
+			if cred.Usage != credential.CredentialUsage_TRUST_CA || cred.Subject != subject {
+				continue
+			}
+			if cred.CertificatesError == nil && containsCertificate(cred.Certificates, newCA) {
+				present = true
+				continue
+			}
+			previous = append(previous, cred.ID)
+		}
+		if present {
+			return nil
+		}
+		if err = p.AddCertificateAuthority(ctx, subject, newCA); err != nil {
+			return fmt.Errorf("cannot add certificate authority: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
@@ -0,0 +1,89 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T, cn string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestToDeviceCredentials(t *testing.T) {
+	ca := newTestCertificate(t, "ca")
+	other := newTestCertificate(t, "other")
+	creds := toDeviceCredentials([]credential.Credential{
+		{
+			ID:      1,
+			Type:    credential.CredentialType_ASYMMETRIC_SIGNING_WITH_CERTIFICATE,
+			Subject: "*",
+			Usage:   credential.CredentialUsage_TRUST_CA,
+			PublicData: &credential.CredentialPublicData{
+				DataInternal: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})),
+				Encoding:     credential.CredentialPublicDataEncoding_PEM,
+			},
+		},
+		{
+			ID:      2,
+			Type:    credential.CredentialType_ASYMMETRIC_SIGNING_WITH_CERTIFICATE,
+			Subject: "*",
+			Usage:   credential.CredentialUsage_TRUST_CA,
+			PublicData: &credential.CredentialPublicData{
+				DataInternal: "invalid",
+				Encoding:     credential.CredentialPublicDataEncoding_PEM,
+			},
+		},
+		{
+			ID:      3,
+			Type:    credential.CredentialType_SYMMETRIC_PAIR_WISE,
+			Subject: "00000000-0000-0000-0000-000000000001",
+		},
+	})
+	require.Len(t, creds, 3)
+	require.NoError(t, creds[0].CertificatesError)
+	require.Len(t, creds[0].Certificates, 1)
+	require.True(t, containsCertificate(creds[0].Certificates, ca))
+	require.False(t, containsCertificate(creds[0].Certificates, other))
+	require.Equal(t, ca.NotAfter, creds[0].Certificates[0].NotAfter)
+	require.Error(t, creds[1].CertificatesError)
+	require.Empty(t, creds[2].Certificates)
+	require.NoError(t, creds[2].CertificatesError)
+}
@@ -35,6 +35,7 @@ func (c *Client) DeleteDevices(ctx context.Context, deviceIDFilter []string) []s
 	deviceIDs := make([]string, 0, len(devs))
 	for _, d := range devs {
 		deviceIDs = append(deviceIDs, d.DeviceID())
+		c.deleteIntrospectionCacheKey(d.DeviceID())
 		err := d.Close(ctx)
 		if err != nil {
 			c.logger.Debugf("can't close device %v during deleting device from the cache: %v", d.DeviceID(), err)
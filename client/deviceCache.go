@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -34,6 +35,12 @@ type DeviceCache struct {
 
 	closed atomic.Bool
 	done   chan struct{}
+
+	storeLock sync.Mutex
+	store     DeviceCacheStore
+	records   map[string]DeviceCacheRecord
+	// restored contains devices loaded from the store which were not used yet.
+	restored map[string]struct{}
 }
 
 // NewDeviceCache creates a new cache for devices.
@@ -62,7 +69,120 @@ func NewDeviceCache(deviceExpiration, pollInterval time.Duration, logger core.Lo
 		deviceExpiration: deviceExpiration,
 		logger:           logger,
 		done:             done,
+		records:          make(map[string]DeviceCacheRecord),
+		restored:         make(map[string]struct{}),
+	}
+}
+
+// Restore sets the store of the cache and loads the stored devices. The devices found by IP are stored without
+// expiration, the others with the default expiration. Restored devices are revalidated by the client on the first use.
+func (c *DeviceCache) Restore(store DeviceCacheStore, newDevice func(record DeviceCacheRecord) *core.Device) error {
+	records, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load devices: %w", err)
+	}
+	c.storeLock.Lock()
+	c.store = store
+	c.storeLock.Unlock()
+	for _, r := range records {
+		if r.ID == "" {
+			continue
+		}
+		dev := newDevice(r)
+		expiration := getNextExpiration(c.deviceExpiration)
+		if r.FoundByIP != "" {
+			expiration = time.Time{}
+		}
+		if _, loaded := c.devicesCache.LoadOrStore(r.ID, c.newElement(dev, expiration)); loaded {
+			continue
+		}
+		c.storeLock.Lock()
+		c.records[r.ID] = r
+		c.restored[r.ID] = struct{}{}
+		c.storeLock.Unlock()
+	}
+	return nil
+}
+
+// isRestored returns true when the device was restored from the store and it was not revalidated yet.
+func (c *DeviceCache) isRestored(deviceID string) bool {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	_, ok := c.restored[deviceID]
+	return ok
+}
+
+// setRevalidated marks the restored device as revalidated, so its endpoints are trusted from now on.
+func (c *DeviceCache) setRevalidated(deviceID string) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	delete(c.restored, deviceID)
+}
+
+func (c *DeviceCache) saveRecordLocked(r DeviceCacheRecord) {
+	if prev, ok := c.records[r.ID]; ok && prev.equal(r) {
+		return
+	}
+	c.records[r.ID] = r
+	if c.store == nil {
+		return
+	}
+	if err := c.store.Save(r); err != nil {
+		c.logger.Warn(fmt.Errorf("can't store device (%v): %w", r.ID, err).Error())
+	}
+}
+
+func (c *DeviceCache) persistDevice(device *core.Device) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	r := DeviceCacheRecord{
+		ID:          device.DeviceID(),
+		Endpoints:   device.GetEndpoints(),
+		FoundByIP:   device.FoundByIP(),
+		DeviceTypes: device.DeviceTypes(),
+		OwnerID:     c.records[device.DeviceID()].OwnerID,
 	}
+	c.saveRecordLocked(r)
+}
+
+func (c *DeviceCache) forgetDevice(deviceID string) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	delete(c.restored, deviceID)
+	if _, ok := c.records[deviceID]; !ok {
+		return
+	}
+	delete(c.records, deviceID)
+	if c.store == nil {
+		return
+	}
+	if err := c.store.Delete(deviceID); err != nil {
+		c.logger.Warn(fmt.Errorf("can't delete stored device (%v): %w", deviceID, err).Error())
+	}
+}
+
+// SetDeviceOwnerID stores the owner of the device in the cache, it returns false when the device is not in the cache.
+func (c *DeviceCache) SetDeviceOwnerID(deviceID, ownerID string) bool {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	r, ok := c.records[deviceID]
+	if !ok {
+		return false
+	}
+	r.OwnerID = ownerID
+	c.saveRecordLocked(r)
+	return true
+}
+
+// GetDeviceOwnerID returns the owner of the device from the last ownership check.
+func (c *DeviceCache) GetDeviceOwnerID(deviceID string) (string, bool) {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	r, ok := c.records[deviceID]
+	if !ok || r.OwnerID == "" {
+		return "", false
+	}
+	return r.OwnerID, true
 }
 
 // LoadAndDeleteDevice loads the device from the cache and deletes it from the cache. To cleanup
@@ -150,18 +270,27 @@ func deviceIsStoredWithExpiration(e *cache.Element[*core.Device]) bool {
 	return !e.ValidUntil.Load().IsZero()
 }
 
-func (c *DeviceCache) updateOrStoreDevice(device *core.Device, expiration time.Time) (*core.Device, bool) {
+func (c *DeviceCache) newElement(device *core.Device, expiration time.Time) *cache.Element[*core.Device] {
 	deviceID := device.DeviceID()
-	// if the device was not in the cache store it
-	loadedDev, loaded := c.devicesCache.LoadOrStore(deviceID, cache.NewElement(device, expiration, func(d1 *core.Device) {
+	return cache.NewElement(device, expiration, func(d1 *core.Device) {
+		if c.devicesCache.Load(deviceID) == nil {
+			c.forgetDevice(deviceID)
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 		defer cancel()
 		err := d1.Close(ctx)
 		if err != nil {
 			c.logger.Warn(fmt.Errorf("can't close device (%v) when evicting from cache: %w", deviceID, err).Error())
 		}
-	}))
+	})
+}
+
+func (c *DeviceCache) updateOrStoreDevice(device *core.Device, expiration time.Time) (*core.Device, bool) {
+	deviceID := device.DeviceID()
+	// if the device was not in the cache store it
+	loadedDev, loaded := c.devicesCache.LoadOrStore(deviceID, c.newElement(device, expiration))
 	dev := loadedDev.Data()
+	defer c.persistDevice(dev)
 	if loaded {
 		dev.UpdateBy(device)
 		// record is already in cache
@@ -196,6 +325,7 @@ func (c *DeviceCache) LoadAndDeleteDevices(deviceIDFilter []string) []*core.Devi
 	devices := make([]*core.Device, 0, len(deviceIDFilter))
 	if len(deviceIDFilter) == 0 {
 		for _, d := range c.devicesCache.LoadAndDeleteAll() {
+			c.forgetDevice(d.Data().DeviceID())
 			devices = append(devices, d.Data())
 		}
 		return devices
@@ -205,6 +335,7 @@ func (c *DeviceCache) LoadAndDeleteDevices(deviceIDFilter []string) []*core.Devi
 		if !ok {
 			continue
 		}
+		c.forgetDevice(deviceID)
 		devices = append(devices, d.Data())
 	}
 	return devices
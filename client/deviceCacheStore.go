@@ -0,0 +1,166 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/plgd-dev/device/v2/pkg/atomicfile"
+	"github.com/plgd-dev/device/v2/schema"
+)
+
+// DeviceCacheRecord is the persisted state of the device from the DeviceCache.
+type DeviceCacheRecord struct {
+	ID          string           `json:"id"`
+	Endpoints   schema.Endpoints `json:"endpoints,omitempty"`
+	FoundByIP   string           `json:"foundByIp,omitempty"`
+	DeviceTypes []string         `json:"deviceTypes,omitempty"`
+	// OwnerID is the owner of the device from the last ownership check, empty when it is unknown.
+	OwnerID string `json:"ownerId,omitempty"`
+}
+
+func (r DeviceCacheRecord) equal(v DeviceCacheRecord) bool {
+	return r.ID == v.ID && r.FoundByIP == v.FoundByIP && r.OwnerID == v.OwnerID &&
+		slices.Equal(r.Endpoints, v.Endpoints) && slices.Equal(r.DeviceTypes, v.DeviceTypes)
+}
+
+// DeviceCacheStore persists the devices of the DeviceCache, so they are available after the restart of the client.
+type DeviceCacheStore interface {
+	// Load returns all stored records.
+	Load() ([]DeviceCacheRecord, error)
+	// Save creates or replaces the record of the device.
+	Save(record DeviceCacheRecord) error
+	// Delete removes the record of the device, missing record is not an error.
+	Delete(deviceID string) error
+}
+
+// FileDeviceCacheStore stores the records of the devices in a single JSON file.
+type FileDeviceCacheStore struct {
+	path    string
+	lock    sync.Mutex
+	records map[string]DeviceCacheRecord
+}
+
+// NewFileDeviceCacheStore creates a store backed by the file, the file is created by the first Save.
+func NewFileDeviceCacheStore(path string) (*FileDeviceCacheStore, error) {
+	if path == "" {
+		return nil, errors.New("invalid path")
+	}
+	s := &FileDeviceCacheStore{
+		path: path,
+	}
+	records, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	s.records = records
+	return s, nil
+}
+
+func (s *FileDeviceCacheStore) read() (map[string]DeviceCacheRecord, error) {
+	records := make(map[string]DeviceCacheRecord)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read device cache file %v: %w", s.path, err)
+	}
+	var v []DeviceCacheRecord
+	if err = json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("cannot decode device cache file %v: %w", s.path, err)
+	}
+	for _, r := range v {
+		if r.ID == "" {
+			continue
+		}
+		records[r.ID] = r
+	}
+	return records, nil
+}
+
+// write replaces the file atomically, so the file is not corrupted when the process is killed.
+func (s *FileDeviceCacheStore) write() error {
+	v := make([]DeviceCacheRecord, 0, len(s.records))
+	for _, r := range s.records {
+		v = append(v, r)
+	}
+	sort.Slice(v, func(i, j int) bool { return v[i].ID < v[j].ID })
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.path)
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	if err = atomicfile.Write(s.path, data); err != nil {
+		return fmt.Errorf("cannot write device cache file %v: %w", s.path, err)
+	}
+	return nil
+}
+
+func (s *FileDeviceCacheStore) Load() ([]DeviceCacheRecord, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	records := make([]DeviceCacheRecord, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func (s *FileDeviceCacheStore) Save(record DeviceCacheRecord) error {
+	if record.ID == "" {
+		return errors.New("invalid device ID")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	prev, ok := s.records[record.ID]
+	s.records[record.ID] = record
+	if err := s.write(); err != nil {
+		if ok {
+			s.records[record.ID] = prev
+		} else {
+			delete(s.records, record.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *FileDeviceCacheStore) Delete(deviceID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	prev, ok := s.records[deviceID]
+	if !ok {
+		return nil
+	}
+	delete(s.records, deviceID)
+	if err := s.write(); err != nil {
+		s.records[deviceID] = prev
+		return err
+	}
+	return nil
+}
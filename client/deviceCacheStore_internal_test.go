@@ -0,0 +1,148 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/log"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileDeviceCacheStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "devices.json")
+	_, err := NewFileDeviceCacheStore("")
+	require.Error(t, err)
+
+	s, err := NewFileDeviceCacheStore(path)
+	require.NoError(t, err)
+	records, err := s.Load()
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	r1 := DeviceCacheRecord{
+		ID:          "1",
+		Endpoints:   schema.Endpoints{{URI: "coaps+tcp://127.0.0.1:1234", Priority: 1}},
+		FoundByIP:   "127.0.0.1",
+		DeviceTypes: []string{"oic.wk.d"},
+		OwnerID:     "owner",
+	}
+	r2 := DeviceCacheRecord{
+		ID: "2",
+	}
+	require.NoError(t, s.Save(r1))
+	require.NoError(t, s.Save(r2))
+	require.Error(t, s.Save(DeviceCacheRecord{}))
+	require.NoError(t, s.Delete("2"))
+	require.NoError(t, s.Delete("unknown"))
+
+	s, err = NewFileDeviceCacheStore(path)
+	require.NoError(t, err)
+	records, err = s.Load()
+	require.NoError(t, err)
+	require.Equal(t, []DeviceCacheRecord{r1}, records)
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+	_, err = NewFileDeviceCacheStore(path)
+	require.Error(t, err)
+}
+
+func TestDeviceCacheRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "devices.json")
+	store, err := NewFileDeviceCacheStore(path)
+	require.NoError(t, err)
+	newDevice := func(r DeviceCacheRecord) *core.Device {
+		return core.NewDevice(core.DeviceConfiguration{}, r.ID, r.DeviceTypes, func() schema.Endpoints { return r.Endpoints })
+	}
+
+	c := NewDeviceCache(time.Minute, time.Second, log.NewNilLogger())
+	require.NoError(t, c.Restore(store, newDevice))
+	endpoints := schema.Endpoints{{URI: "coaps+tcp://127.0.0.1:1234"}}
+	dev := core.NewDevice(core.DeviceConfiguration{}, "1", []string{"oic.wk.d"}, func() schema.Endpoints { return endpoints })
+	_, loaded := c.UpdateOrStoreDeviceWithExpiration(dev)
+	require.False(t, loaded)
+	dev2 := core.NewDevice(core.DeviceConfiguration{}, "2", nil, func() schema.Endpoints { return nil })
+	_, loaded = c.UpdateOrStoreDevice(dev2)
+	require.False(t, loaded)
+	require.True(t, c.SetDeviceOwnerID("1", "owner"))
+	require.False(t, c.SetDeviceOwnerID("unknown", "owner"))
+	devs := c.LoadAndDeleteDevices([]string{"2"})
+	require.Len(t, devs, 1)
+	require.NoError(t, c.Close(context.Background()))
+
+	store, err = NewFileDeviceCacheStore(path)
+	require.NoError(t, err)
+	c = NewDeviceCache(time.Minute, time.Second, log.NewNilLogger())
+	require.NoError(t, c.Restore(store, newDevice))
+	defer func() {
+		require.NoError(t, c.Close(context.Background()))
+	}()
+	d, ok := c.GetDevice("1")
+	require.True(t, ok)
+	require.Equal(t, endpoints, d.GetEndpoints())
+	require.Equal(t, []string{"oic.wk.d"}, d.DeviceTypes())
+	ownerID, ok := c.GetDeviceOwnerID("1")
+	require.True(t, ok)
+	require.Equal(t, "owner", ownerID)
+	exp, ok := c.GetDeviceExpiration("1")
+	require.True(t, ok)
+	require.False(t, exp.IsZero())
+	_, ok = c.GetDevice("2")
+	require.False(t, ok)
+
+	require.True(t, c.isRestored("1"))
+	c.setRevalidated("1")
+	require.False(t, c.isRestored("1"))
+}
+
+func TestStoreOwnershipsFromDeviceCache(t *testing.T) {
+	store, err := NewFileDeviceCacheStore(filepath.Join(t.TempDir(), "devices.json"))
+	require.NoError(t, err)
+	for _, r := range []DeviceCacheRecord{
+		{ID: "owned", OwnerID: "owner"},
+		{ID: "other", OwnerID: "other"},
+		{ID: "unknown"},
+	} {
+		require.NoError(t, store.Save(r))
+	}
+	c := &Client{
+		deviceCache: NewDeviceCache(time.Minute, time.Second, log.NewNilLogger()),
+	}
+	defer func() {
+		require.NoError(t, c.deviceCache.Close(context.Background()))
+	}()
+	require.NoError(t, c.deviceCache.Restore(store, func(r DeviceCacheRecord) *core.Device {
+		return core.NewDevice(core.DeviceConfiguration{}, r.ID, r.DeviceTypes, func() schema.Endpoints { return r.Endpoints })
+	}))
+
+	// the ownership of the devices cannot be obtained, so the stored owners are used
+	devs := map[string]DeviceDetails{
+		"owned":   {ID: "owned", IsSecured: true, OwnershipStatus: OwnershipStatus_Unknown},
+		"other":   {ID: "other", IsSecured: true, OwnershipStatus: OwnershipStatus_Unknown},
+		"unknown": {ID: "unknown", IsSecured: true, OwnershipStatus: OwnershipStatus_Unknown},
+	}
+	c.storeOwnerships("owner", devs)
+	require.Equal(t, OwnershipStatus_Owned, devs["owned"].OwnershipStatus)
+	require.Equal(t, OwnershipStatus_OwnedByOther, devs["other"].OwnershipStatus)
+	require.Equal(t, OwnershipStatus_Unknown, devs["unknown"].OwnershipStatus)
+}
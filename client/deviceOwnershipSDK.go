@@ -31,6 +31,7 @@ import (
 	"github.com/plgd-dev/device/v2/client/core/otm"
 	justworks "github.com/plgd-dev/device/v2/client/core/otm/just-works"
 	"github.com/plgd-dev/device/v2/client/core/otm/manufacturer"
+	randompin "github.com/plgd-dev/device/v2/client/core/otm/random-pin"
 	pkgX509 "github.com/plgd-dev/device/v2/pkg/security/x509"
 )
 
@@ -131,6 +132,14 @@ func getOTMManufacturer(app ApplicationCallback, dialTLS core.DialTLS,
 	return manufacturer.NewClient(mfgCert, mfgCA, manufacturer.WithDialDTLS(dialDTLS), manufacturer.WithDialTLS(dialTLS)), nil
 }
 
+func getOTMRandomPin(app ApplicationCallback, dialDTLS core.DialDTLS) (otm.Client, error) {
+	pinApp, ok := app.(RandomPinApplicationCallback)
+	if !ok {
+		return nil, errors.New("application callback doesn't implement GetRandomPin")
+	}
+	return randompin.NewClient(pinApp.GetRandomPin, randompin.WithDialDTLS(dialDTLS)), nil
+}
+
 func getOtmClients(app ApplicationCallback, dialTLS core.DialTLS, dialDTLS core.DialDTLS, otmTypes []OTMType) ([]otm.Client, error) {
 	otmClients := make([]otm.Client, 0, 2)
 	for _, otmType := range otmTypes {
@@ -143,6 +152,12 @@ func getOtmClients(app ApplicationCallback, dialTLS core.DialTLS, dialDTLS core.
 			otmClients = append(otmClients, otm)
 		case OTMType_JustWorks:
 			otmClients = append(otmClients, justworks.NewClient(justworks.WithDialDTLS(dialDTLS)))
+		case OTMType_RandomPin:
+			otm, err := getOTMRandomPin(app, dialDTLS)
+			if err != nil {
+				return nil, err
+			}
+			otmClients = append(otmClients, otm)
 		default:
 			return nil, fmt.Errorf("unsupported ownership transfer method: %v", otmType)
 		}
@@ -0,0 +1,191 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/plgdtime"
+)
+
+// DeviceTimeDrift is a result of the time synchronization of the device.
+type DeviceTimeDrift struct {
+	DeviceID string
+	// DeviceTime is the time reported by the device.
+	DeviceTime time.Time
+	// LocalTime is the local time when the device time was read, it is compensated by half of the round trip time.
+	LocalTime time.Time
+	// Drift is the difference between the device time and the local time, a positive value means the device clock is ahead.
+	Drift time.Duration
+	// Corrected is true when the time of the device has been updated.
+	Corrected bool
+	// Err is set when the time of the device cannot be read or updated.
+	Err error
+}
+
+func (c *Client) getDeviceTimeLink(ctx context.Context, deviceID string, cfg commonCommandOptions) (*core.Device, schema.ResourceLink, error) {
+	d, links, err := c.GetDevice(ctx, deviceID, WithDiscoveryConfiguration(cfg.discoveryConfiguration))
+	if err != nil {
+		return nil, schema.ResourceLink{}, err
+	}
+	links = links.GetResourceLinks(plgdtime.ResourceType)
+	if len(links) == 0 {
+		return nil, schema.ResourceLink{}, core.MakeUnavailable(fmt.Errorf("cannot find '%v' in device %v", plgdtime.ResourceType, deviceID))
+	}
+	return d, links[0], nil
+}
+
+func (c *Client) getDeviceTime(ctx context.Context, d *core.Device, link schema.ResourceLink, cfg commonCommandOptions) (plgdtime.PlgdTime, error) {
+	if c.useDeviceIDInQuery {
+		cfg.opts = append(cfg.opts, coap.WithDeviceID(d.DeviceID()))
+	}
+	var resp plgdtime.PlgdTime
+	err := d.GetResource(ctx, link, &resp, cfg.opts...)
+	if err != nil {
+		return plgdtime.PlgdTime{}, err
+	}
+	return resp, nil
+}
+
+func (c *Client) setDeviceTime(ctx context.Context, d *core.Device, link schema.ResourceLink, t time.Time, cfg commonCommandOptions) error {
+	if c.useDeviceIDInQuery {
+		cfg.opts = append(cfg.opts, coap.WithDeviceID(d.DeviceID()))
+	}
+	return d.UpdateResource(ctx, link, plgdtime.PlgdTimeUpdate{
+		Time: t.UTC().Format(time.RFC3339Nano),
+	}, nil, cfg.opts...)
+}
+
+// GetDeviceTime returns the time resource of the device.
+func (c *Client) GetDeviceTime(ctx context.Context, deviceID string, opts ...CommonCommandOption) (plgdtime.PlgdTime, error) {
+	cfg := applyCommonOptions(opts...)
+	d, link, err := c.getDeviceTimeLink(ctx, deviceID, cfg)
+	if err != nil {
+		return plgdtime.PlgdTime{}, err
+	}
+	return c.getDeviceTime(ctx, d, link, cfg)
+}
+
+// SetDeviceTime sets the time of the device.
+func (c *Client) SetDeviceTime(ctx context.Context, deviceID string, t time.Time, opts ...CommonCommandOption) error {
+	cfg := applyCommonOptions(opts...)
+	d, link, err := c.getDeviceTimeLink(ctx, deviceID, cfg)
+	if err != nil {
+		return err
+	}
+	return c.setDeviceTime(ctx, d, link, t, cfg)
+}
+
+// computeTimeDrift computes the drift of the device clock. The device time is compared with the middle of the request.
+func computeTimeDrift(deviceTime, requestStart, requestEnd time.Time) (time.Time, time.Duration) {
+	localTime := requestStart.Add(requestEnd.Sub(requestStart) / 2)
+	return localTime, deviceTime.Sub(localTime)
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func (c *Client) syncDeviceTime(ctx context.Context, deviceID string, threshold time.Duration, opts ...CommonCommandOption) DeviceTimeDrift {
+	res := DeviceTimeDrift{
+		DeviceID: deviceID,
+	}
+	cfg := applyCommonOptions(opts...)
+	// the device is resolved before the time is measured, so the drift doesn't include the discovery
+	d, link, err := c.getDeviceTimeLink(ctx, deviceID, cfg)
+	if err != nil {
+		res.Err = fmt.Errorf("cannot get time of device %v: %w", deviceID, err)
+		return res
+	}
+	start := time.Now()
+	t, err := c.getDeviceTime(ctx, d, link, cfg)
+	end := time.Now()
+	if err != nil {
+		res.Err = fmt.Errorf("cannot get time of device %v: %w", deviceID, err)
+		return res
+	}
+	res.DeviceTime, err = t.GetTime()
+	if err != nil {
+		res.Err = fmt.Errorf("cannot parse time of device %v: %w", deviceID, err)
+		return res
+	}
+	res.LocalTime, res.Drift = computeTimeDrift(res.DeviceTime, start, end)
+	if absDuration(res.Drift) <= threshold {
+		return res
+	}
+	err = c.setDeviceTime(ctx, d, link, time.Now(), cfg)
+	if err != nil {
+		res.Err = fmt.Errorf("cannot set time of device %v: %w", deviceID, err)
+		return res
+	}
+	res.Corrected = true
+	return res
+}
+
+// syncDeviceTimesConcurrency limits the number of devices synchronized at once by SyncDeviceTimes.
+const syncDeviceTimesConcurrency = 16
+
+// SyncDeviceTimes reads the clocks of the devices, computes the drift against the local clock and sets the time
+// of the devices whose drift is above the threshold, at most 16 devices at once. When deviceIDs is empty, all devices found by GetDevicesDetails
+// with the time resource are synchronized. It returns the drift report per device.
+func (c *Client) SyncDeviceTimes(ctx context.Context, deviceIDs []string, threshold time.Duration, opts ...CommonCommandOption) (map[string]DeviceTimeDrift, error) {
+	if len(deviceIDs) == 0 {
+		cfg := applyCommonOptions(opts...)
+		devices, err := c.GetDevicesDetails(ctx, WithDiscoveryConfiguration(cfg.discoveryConfiguration))
+		if err != nil {
+			return nil, err
+		}
+		for deviceID, d := range devices {
+			if len(d.Resources.GetResourceLinks(plgdtime.ResourceType)) > 0 {
+				deviceIDs = append(deviceIDs, deviceID)
+			}
+		}
+	}
+
+	var lock sync.Mutex
+	res := make(map[string]DeviceTimeDrift, len(deviceIDs))
+	ids := make(chan string)
+	workers := min(len(deviceIDs), syncDeviceTimesConcurrency)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for deviceID := range ids {
+				drift := c.syncDeviceTime(ctx, deviceID, threshold, opts...)
+				lock.Lock()
+				res[deviceID] = drift
+				lock.Unlock()
+			}
+		}()
+	}
+	for _, deviceID := range deviceIDs {
+		ids <- deviceID
+	}
+	close(ids)
+	wg.Wait()
+	return res, nil
+}
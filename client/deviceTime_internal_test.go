@@ -0,0 +1,37 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTimeDrift(t *testing.T) {
+	start := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Second)
+
+	localTime, drift := computeTimeDrift(start.Add(time.Minute), start, end)
+	require.Equal(t, start.Add(500*time.Millisecond), localTime)
+	require.Equal(t, time.Minute-500*time.Millisecond, drift)
+
+	_, drift = computeTimeDrift(start.Add(-time.Hour), start, end)
+	require.Equal(t, -time.Hour-500*time.Millisecond, drift)
+	require.Equal(t, time.Hour+500*time.Millisecond, absDuration(drift))
+}
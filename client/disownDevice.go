@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/plgd-dev/device/v2/client/core"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
 )
@@ -51,5 +52,9 @@ func (c *Client) DisownDevice(ctx context.Context, deviceID string, opts ...Comm
 		return d.FactoryReset(ctx, links, cfg.opts...)
 	}
 
-	return d.Disown(ctx, links, cfg.opts...)
+	if err = d.Disown(ctx, links, cfg.opts...); err != nil {
+		return err
+	}
+	c.deviceCache.SetDeviceOwnerID(d.DeviceID(), uuid.Nil.String())
+	return nil
 }
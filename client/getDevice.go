@@ -21,6 +21,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/plgd-dev/device/v2/client/core"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
@@ -31,6 +32,9 @@ import (
 	"github.com/plgd-dev/go-coap/v3/message/status"
 )
 
+// restoredDeviceDiscoveryTimeout limits the multicast discovery of the device restored from the store.
+const restoredDeviceDiscoveryTimeout = time.Second * 2
+
 func getLinksDevice(ctx context.Context, dev *core.Device, disableUDPEndpoints bool) (schema.ResourceLinks, error) {
 	endpoints := dev.GetEndpoints()
 	links, err := dev.GetResourceLinks(ctx, endpoints, coap.WithDeviceID(dev.DeviceID()))
@@ -169,6 +173,31 @@ func (c *Client) checkAndUpdateCacheByLinks(ctx context.Context, dev *core.Devic
 	return nil, nil, fmt.Errorf("cannot get device %v: not found", dev.DeviceID())
 }
 
+// revalidateRestoredDevice discovers the device restored from the store again, via the IP address when it was
+// found by IP or via multicast otherwise. The multicast discovery is limited by restoredDeviceDiscoveryTimeout,
+// so the stored endpoints can be still tried when the device doesn't respond.
+func (c *Client) revalidateRestoredDevice(ctx context.Context, dev *core.Device, opts ...GetDeviceOption) (*core.Device, schema.ResourceLinks, error) {
+	if dev.FoundByIP() != "" {
+		devLinks, err := c.getDevicesByIP(ctx, dev.FoundByIP(), dev.DeviceID())
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(devLinks) == 0 {
+			return nil, nil, fmt.Errorf("cannot get device %v: not found", dev.DeviceID())
+		}
+		c.deviceCache.setRevalidated(dev.DeviceID())
+		return devLinks[0].Device, devLinks[0].Links, nil
+	}
+	discoveryCtx, cancel := context.WithTimeout(ctx, restoredDeviceDiscoveryTimeout)
+	defer cancel()
+	d, links, err := c.GetDeviceByMulticast(discoveryCtx, dev.DeviceID(), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.deviceCache.setRevalidated(dev.DeviceID())
+	return d, links, nil
+}
+
 // GetDevice gets the device from the cache or via multicast or via IP address if was previously stored by GetDeviceByIP and updates device in the cache.
 func (c *Client) GetDevice(ctx context.Context, deviceID string, opts ...GetDeviceOption,
 ) (*core.Device, schema.ResourceLinks, error) {
@@ -176,9 +205,20 @@ func (c *Client) GetDevice(ctx context.Context, deviceID string, opts ...GetDevi
 	if !ok {
 		return c.GetDeviceByMulticast(ctx, deviceID, opts...)
 	}
+	if c.deviceCache.isRestored(deviceID) {
+		// the stored endpoints of the restored device are probably stale, so discover the device again
+		if d, links, err := c.revalidateRestoredDevice(ctx, dev, opts...); err == nil {
+			return d, links, nil
+		}
+	}
 	links, err := getLinksDevice(ctx, dev, c.disableUDPEndpoints)
 	if err == nil {
-		return c.checkAndUpdateCacheByLinks(ctx, dev, links)
+		d, links, err := c.checkAndUpdateCacheByLinks(ctx, dev, links)
+		if err == nil {
+			// the device responded on the stored endpoints
+			c.deviceCache.setRevalidated(deviceID)
+		}
+		return d, links, err
 	}
 	if dev.FoundByIP() != "" {
 		devLinks, err := c.getDevicesByIP(ctx, dev.FoundByIP(), deviceID)
@@ -242,11 +282,13 @@ func (c *Client) getDeviceDetails(ctx context.Context, dev *core.Device, links s
 	}
 	ownerID, _ := c.client.GetSdkOwnerID()
 
-	return setOwnership(ownerID, map[string]DeviceDetails{
+	devs := setOwnership(ownerID, map[string]DeviceDetails{
 		devDetails.ID: devDetails,
 	}, map[string]ownership{
 		devDetails.ID: o,
-	})[devDetails.ID], nil
+	})
+	c.storeOwnerships(ownerID, devs)
+	return devs[devDetails.ID], nil
 }
 
 func (c *Client) GetDeviceDetailsByMulticast(ctx context.Context, deviceID string, opts ...GetDeviceOption) (DeviceDetails, error) {
@@ -29,6 +29,7 @@ import (
 	"github.com/plgd-dev/device/v2/schema/device"
 	"github.com/plgd-dev/device/v2/schema/doxm"
 	"github.com/plgd-dev/device/v2/schema/interfaces"
+	"github.com/plgd-dev/device/v2/schema/sdi"
 	"github.com/plgd-dev/go-coap/v3/message"
 	kitStrings "github.com/plgd-dev/kit/v2/strings"
 )
@@ -55,6 +56,11 @@ type ownership struct {
 	status OwnershipStatus
 }
 
+type securityDomain struct {
+	sdi *sdi.SDI
+	err error
+}
+
 func (c *Client) getDevicesAppendDeviceByIP(ctx context.Context, ip string, resourceTypes []string, getDetails func(ctx context.Context, d *core.Device, links schema.ResourceLinks, optsArgs ...func(message.Options) message.Options) (interface{}, error), appendDevice func(d DeviceDetails)) {
 	d, err := c.GetDeviceDetailsByIP(ctx, ip, WithGetDetails(getDetails))
 	if err != nil {
@@ -97,7 +103,14 @@ func (c *Client) GetDevicesDetails(
 		defer m.Unlock()
 		resOwnerships[deviceID] = d
 	}
+	resSecurityDomains := make(map[string]securityDomain)
+	securityDomains := func(deviceID string, sd securityDomain) {
+		m.Lock()
+		defer m.Unlock()
+		resSecurityDomains[deviceID] = sd
+	}
 
+	ownerID, _ := c.client.GetSdkOwnerID()
 	getDetails := func(ctx context.Context, d *core.Device, links schema.ResourceLinks, optsArgs ...func(message.Options) message.Options) (interface{}, error) {
 		links = patchResourceLinksEndpoints(links, c.disableUDPEndpoints)
 		details, err := cfg.getDetails(ctx, d, links, optsArgs...)
@@ -113,6 +126,9 @@ func (c *Client) GetDevicesDetails(
 					status: OwnershipStatus_OwnedByOther,
 				})
 			}
+			if cfg.getSecurityDomain {
+				securityDomains(d.DeviceID(), getSecurityDomain(ctx, d, links, optsArgs...))
+			}
 		}
 		return details, err
 	}
@@ -142,8 +158,10 @@ func (c *Client) GetDevicesDetails(
 	wg.Wait()
 	m.Lock()
 	defer m.Unlock()
-	ownerID, _ := c.client.GetSdkOwnerID()
-	return setOwnership(ownerID, mergeDevices(res), resOwnerships), nil
+	devs := setOwnership(ownerID, mergeDevices(res), resOwnerships)
+	devs = setSecurityDomains(devs, resSecurityDomains)
+	c.storeOwnerships(ownerID, devs)
+	return devs, nil
 }
 
 // GetDevicesWithHandler discovers devices using a CoAP multicast request via UDP.
@@ -190,6 +208,11 @@ type DeviceDetails struct {
 	Endpoints []schema.Endpoint
 	// Ownership status
 	OwnershipStatus OwnershipStatus
+	// SecurityDomain of the secured device, it is set when it is requested by WithSecurityDomainDetails.
+	SecurityDomain *sdi.SDI
+	// SecurityDomainErr is set when the security domain is requested by WithSecurityDomainDetails and it cannot be read,
+	// e.g. the private security domain is readable only by the owner of the device.
+	SecurityDomainErr error
 }
 
 func newDiscoveryHandler(
@@ -334,6 +357,59 @@ func mergeEndpoints(a, b []schema.Endpoint) []schema.Endpoint {
 	return out
 }
 
+// storeOwnerships stores the owners of the devices to the device cache. For secured devices
+// whose ownership cannot be obtained, the status is resolved from the owner stored in the cache.
+func (c *Client) storeOwnerships(ownerID string, devs map[string]DeviceDetails) {
+	for deviceID, d := range devs {
+		if d.Ownership != nil {
+			c.deviceCache.SetDeviceOwnerID(deviceID, d.Ownership.OwnerID)
+			continue
+		}
+		if !d.IsSecured || d.OwnershipStatus != OwnershipStatus_Unknown {
+			continue
+		}
+		if deviceOwnerID, ok := c.deviceCache.GetDeviceOwnerID(deviceID); ok {
+			d.OwnershipStatus = getOwnershipStatus(ownerID, deviceOwnerID)
+			devs[deviceID] = d
+		}
+	}
+}
+
+func getOwnershipStatus(ownerID, deviceOwnerID string) OwnershipStatus {
+	switch deviceOwnerID {
+	case uuid.Nil.String():
+		return OwnershipStatus_ReadyToBeOwned
+	case ownerID:
+		return OwnershipStatus_Owned
+	}
+	return OwnershipStatus_OwnedByOther
+}
+
+// getSecurityDomain reads the security domain of the device, the private security domain is readable only by the owner.
+func getSecurityDomain(ctx context.Context, d *core.Device, links schema.ResourceLinks, optsArgs ...func(message.Options) message.Options) securityDomain {
+	sd, err := d.GetSecurityDomain(ctx, links, optsArgs...)
+	if err != nil {
+		return securityDomain{
+			err: fmt.Errorf("cannot get security domain of device %v: %w", d.DeviceID(), err),
+		}
+	}
+	return securityDomain{
+		sdi: &sd,
+	}
+}
+
+func setSecurityDomains(devs map[string]DeviceDetails, securityDomains map[string]securityDomain) map[string]DeviceDetails {
+	for deviceID, sd := range securityDomains {
+		d, ok := devs[deviceID]
+		if ok && d.SecurityDomain == nil && d.SecurityDomainErr == nil {
+			d.SecurityDomain = sd.sdi
+			d.SecurityDomainErr = sd.err
+			devs[deviceID] = d
+		}
+	}
+	return devs
+}
+
 func setOwnership(ownerID string, devs map[string]DeviceDetails, owns map[string]ownership) map[string]DeviceDetails {
 	for deviceID, o := range owns {
 		d, ok := devs[deviceID]
@@ -342,14 +418,7 @@ func setOwnership(ownerID string, devs map[string]DeviceDetails, owns map[string
 				d.OwnershipStatus = o.status
 			} else {
 				d.Ownership = o.doxm
-				switch o.doxm.OwnerID {
-				case uuid.Nil.String():
-					d.OwnershipStatus = OwnershipStatus_ReadyToBeOwned
-				case ownerID:
-					d.OwnershipStatus = OwnershipStatus_Owned
-				default:
-					d.OwnershipStatus = OwnershipStatus_OwnedByOther
-				}
+				d.OwnershipStatus = getOwnershipStatus(ownerID, o.doxm.OwnerID)
 			}
 			devs[deviceID] = d
 		}
@@ -0,0 +1,134 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/introspection"
+)
+
+// getIntrospectionCacheKey returns the key of the device model, devices without the model number are cached by deviceID.
+func getIntrospectionCacheKey(deviceID string, d device.Device) string {
+	if d.ModelNumber == "" {
+		return "di:" + deviceID
+	}
+	return strings.Join([]string{"model", d.GetManufacturerName(), d.ModelNumber, d.SoftwareVersion}, ":")
+}
+
+// evictIntrospection removes the introspection document cached for the device, e.g. the software update can change
+// the document without changing the model of the device.
+func (c *Client) evictIntrospection(deviceID string) {
+	if key, ok := c.introspectionCacheKeys.LoadAndDelete(deviceID); ok {
+		c.introspectionCache.Delete(key)
+	}
+}
+
+// deleteIntrospectionCacheKey forgets the cache key of the deleted device, the document is removed only when it is
+// cached for the device, the document of the model stays cached for the other devices.
+func (c *Client) deleteIntrospectionCacheKey(deviceID string) {
+	key, ok := c.introspectionCacheKeys.LoadAndDelete(deviceID)
+	if ok && key == getIntrospectionCacheKey(deviceID, device.Device{}) {
+		c.introspectionCache.Delete(key)
+	}
+}
+
+// getIntrospectionDataLink returns the link of the introspection document, it is served by the same endpoints as the introspection resource.
+func getIntrospectionDataLink(link schema.ResourceLink, info introspection.Introspection) (schema.ResourceLink, error) {
+	for _, u := range info.URLInfo {
+		p, err := url.Parse(u.URL)
+		if err != nil || p.Path == "" {
+			continue
+		}
+		link.Href = p.Path
+		return link, nil
+	}
+	return schema.ResourceLink{}, fmt.Errorf("cannot find valid url in %+v", info.URLInfo)
+}
+
+func (c *Client) getIntrospection(ctx context.Context, deviceID string, d *core.Device, links schema.ResourceLinks, opts []coap.OptionFunc) (*introspection.Document, error) {
+	deviceLinks := links.GetResourceLinks(device.ResourceType)
+	if len(deviceLinks) == 0 {
+		return nil, fmt.Errorf("cannot find '%v'", device.ResourceType)
+	}
+	var dev device.Device
+	if err := d.GetResource(ctx, deviceLinks[0], &dev, opts...); err != nil {
+		return nil, fmt.Errorf("cannot get device resource: %w", err)
+	}
+	key := getIntrospectionCacheKey(deviceID, dev)
+	c.introspectionCacheKeys.Store(deviceID, key)
+	if doc, ok := c.introspectionCache.Load(key); ok {
+		return doc, nil
+	}
+
+	introspectionLinks := links.GetResourceLinks(introspection.ResourceType)
+	if len(introspectionLinks) == 0 {
+		return nil, core.MakeUnavailable(fmt.Errorf("cannot find '%v'", introspection.ResourceType))
+	}
+	var info introspection.Introspection
+	if err := d.GetResource(ctx, introspectionLinks[0], &info, opts...); err != nil {
+		return nil, fmt.Errorf("cannot get introspection resource: %w", err)
+	}
+	link, err := getIntrospectionDataLink(introspectionLinks[0], info)
+	if err != nil {
+		return nil, err
+	}
+	var doc introspection.Document
+	if err = d.GetResource(ctx, link, &doc, opts...); err != nil {
+		return nil, fmt.Errorf("cannot get introspection document %v: %w", link.Href, err)
+	}
+	c.introspectionCache.Store(key, &doc)
+	return &doc, nil
+}
+
+// GetIntrospection returns the introspection document of the device. The document is cached per device model,
+// which is identified by the manufacturer name, the model number and the software version. The document is evicted
+// when the software update of the device succeeds.
+func (c *Client) GetIntrospection(ctx context.Context, deviceID string, opts ...CommonCommandOption) (*introspection.Document, error) {
+	cfg := applyCommonOptions(opts...)
+	d, links, err := c.GetDevice(ctx, deviceID, WithDiscoveryConfiguration(cfg.discoveryConfiguration))
+	if err != nil {
+		return nil, err
+	}
+	if c.useDeviceIDInQuery {
+		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
+	}
+	doc, err := c.getIntrospection(ctx, deviceID, d, links, cfg.opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get introspection of device %v: %w", deviceID, err)
+	}
+	return doc, nil
+}
+
+func (c *Client) validatePayload(ctx context.Context, deviceID string, discoveryConfiguration core.DiscoveryConfiguration, validate func(doc *introspection.Document) error) error {
+	doc, err := c.GetIntrospection(ctx, deviceID, WithDiscoveryConfiguration(discoveryConfiguration))
+	if err != nil {
+		return err
+	}
+	err = validate(doc)
+	if err != nil {
+		return core.MakeInvalidArgument(err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/introspection"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIntrospectionCacheKey(t *testing.T) {
+	d := device.Device{
+		ManufacturerName: []device.LocalizedString{{Language: "en", Value: "plgd"}},
+		ModelNumber:      "model",
+		SoftwareVersion:  "1.0",
+	}
+	require.Equal(t, "model:plgd:model:1.0", getIntrospectionCacheKey("d1", d))
+	require.Equal(t, getIntrospectionCacheKey("d1", d), getIntrospectionCacheKey("d2", d))
+	d.SoftwareVersion = "1.1"
+	require.Equal(t, "model:plgd:model:1.1", getIntrospectionCacheKey("d1", d))
+	require.Equal(t, "di:d1", getIntrospectionCacheKey("d1", device.Device{}))
+}
+
+func TestEvictIntrospection(t *testing.T) {
+	c := &Client{
+		introspectionCache:     coapSync.NewMap[string, *introspection.Document](),
+		introspectionCacheKeys: coapSync.NewMap[string, string](),
+	}
+	modelKey := "model:plgd:model:1.0"
+	c.introspectionCache.Store(modelKey, &introspection.Document{})
+	c.introspectionCacheKeys.Store("d1", modelKey)
+	c.introspectionCacheKeys.Store("d2", modelKey)
+	c.introspectionCache.Store("di:d3", &introspection.Document{})
+	c.introspectionCacheKeys.Store("d3", "di:d3")
+
+	// the document of the model is kept for the other devices
+	c.deleteIntrospectionCacheKey("d1")
+	_, ok := c.introspectionCache.Load(modelKey)
+	require.True(t, ok)
+	c.deleteIntrospectionCacheKey("d3")
+	_, ok = c.introspectionCache.Load("di:d3")
+	require.False(t, ok)
+
+	// the software update evicts the document
+	c.evictIntrospection("d2")
+	_, ok = c.introspectionCache.Load(modelKey)
+	require.False(t, ok)
+}
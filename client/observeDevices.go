@@ -160,6 +160,15 @@ func (o *listDeviceIds) Handle(_ context.Context, client *client.Conn, dev schem
 	o.devices.Store(d.GetDeviceID(), struct{}{})
 }
 
+// handleLinks stores the devices from the links of the resource directory, the links can belong to multiple devices.
+func (o *listDeviceIds) handleLinks(links schema.ResourceLinks) {
+	for _, d := range links.GetResourceLinks(device.ResourceType) {
+		if deviceID := d.GetDeviceID(); deviceID != "" {
+			o.devices.Store(deviceID, struct{}{})
+		}
+	}
+}
+
 // Error gets errors during discovery.
 func (o *listDeviceIds) Error(err error) {
 	if o.err != nil {
@@ -167,7 +176,7 @@ func (o *listDeviceIds) Error(err error) {
 	}
 }
 
-func (o *devicesObserver) discover(ctx context.Context, handler core.DiscoverDevicesHandler) error {
+func (o *devicesObserver) discover(ctx context.Context, handler *listDeviceIds) error {
 	multicastConn, err := core.DialDiscoveryAddresses(ctx, o.discoveryConfiguration, func(err error) { o.c.logger.Debug(err.Error()) })
 	if err != nil {
 		return fmt.Errorf("could not discover devices: %w", err)
@@ -179,6 +188,13 @@ func (o *devicesObserver) discover(ctx context.Context, handler core.DiscoverDev
 			}
 		}
 	}()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		o.c.client.LookupResourceDirectories(ctx, o.discoveryConfiguration, handler.handleLinks, handler.Error, coap.WithResourceType(device.ResourceType))
+	}()
+	defer wg.Wait()
 	// we want to just get "oic.wk.d" resource, because links will be get via unicast to /oic/res
 	return core.DiscoverDevices(ctx, multicastConn, handler, coap.WithResourceType(device.ResourceType))
 }
@@ -307,6 +323,7 @@ func (c *Client) stopObservingDevices(observationID string) (sync func(), ok boo
 
 // ObserveDevices method starts observing devices via multicast
 // and added by IP in poll interval configured in observerPollingInterval.
+// Use WithPresenceStrategy(PresenceStrategy_Passive) to detect offline devices by their connections instead of polling.
 func (c *Client) ObserveDevices(handler DevicesObservationHandler, opts ...ObserveDevicesOption) (string, error) {
 	cfg := observeDevicesOptions{
 		discoveryConfiguration: core.DefaultDiscoveryConfiguration(),
@@ -320,12 +337,18 @@ func (c *Client) ObserveDevices(handler DevicesObservationHandler, opts ...Obser
 		return "", err
 	}
 
-	obs := newDevicesObserver(c, c.observerConfig, cfg.discoveryConfiguration, &devicesObservationHandler{
+	obsHandler := &devicesObservationHandler{
 		handler: handler,
 		removeSubscription: func() {
 			c.stopObservingDevices(ID.String())
 		},
-	})
+	}
+	var obs subscription
+	if cfg.presenceStrategy == PresenceStrategy_Passive {
+		obs = newPassiveDevicesObserver(c, c.observerConfig, cfg.discoveryConfiguration, obsHandler)
+	} else {
+		obs = newDevicesObserver(c, c.observerConfig, cfg.discoveryConfiguration, obsHandler)
+	}
 
 	c.insertSubscription(ID.String(), obs)
 	return ID.String(), nil
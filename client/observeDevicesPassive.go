@@ -0,0 +1,271 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/schema/device"
+	"github.com/plgd-dev/device/v2/schema/resources"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"go.uber.org/atomic"
+)
+
+type PresenceStrategy uint8
+
+const (
+	// PresenceStrategy_Polling repeats the multicast discovery in the polling interval, the device is offline
+	// when it is not discovered FailureThreshold times in a row.
+	PresenceStrategy_Polling PresenceStrategy = 0
+	// PresenceStrategy_Passive observes /oic/d (or /oic/res) of every online device over its connection, the device
+	// is offline when the observation is closed, e.g. by connection close or keepalive failure. The multicast discovery
+	// in the polling interval is used only to find new devices.
+	PresenceStrategy_Passive PresenceStrategy = 1
+)
+
+// presenceObservationHandler handles the observation which keeps the device online.
+type presenceObservationHandler struct {
+	observer      *passiveDevicesObserver
+	deviceID      string
+	observationID string
+	closed        atomic.Bool
+}
+
+func (h *presenceObservationHandler) Handle(context.Context, func(v interface{}) error) {
+	// the content is not important, the device is online while the observation is active
+}
+
+func (h *presenceObservationHandler) OnClose() {
+	h.setOffline()
+}
+
+func (h *presenceObservationHandler) Error(err error) {
+	h.observer.c.logger.Debugf("presence observation of device %v error: %w", h.deviceID, err)
+	h.setOffline()
+}
+
+func (h *presenceObservationHandler) setOffline() {
+	if !h.closed.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		select {
+		case h.observer.offline <- h:
+		case <-h.observer.done:
+		}
+	}()
+}
+
+type presenceDiscoveryHandler struct {
+	c         *Client
+	deviceIDs *coapSync.Map[string, struct{}]
+}
+
+func (h *presenceDiscoveryHandler) Handle(ctx context.Context, newdev *core.Device) {
+	dev, loaded := h.c.deviceCache.UpdateOrStoreDeviceWithExpiration(newdev)
+	if loaded {
+		if errC := newdev.Close(ctx); errC != nil {
+			h.c.logger.Debugf("presence discovery error: %w", errC)
+		}
+	}
+	h.deviceIDs.Store(dev.DeviceID(), struct{}{})
+}
+
+func (h *presenceDiscoveryHandler) Error(err error) {
+	h.c.logger.Debug(err.Error())
+}
+
+type passiveDevicesObserver struct {
+	c                      *Client
+	handler                *devicesObservationHandler
+	discoveryConfiguration core.DiscoveryConfiguration
+	observerConfiguration  ObserverConfig
+
+	cancel  context.CancelFunc
+	wait    func()
+	done    chan struct{}
+	offline chan *presenceObservationHandler
+	// onlineDevices is accessed only by the run goroutine
+	onlineDevices map[string]*presenceObservationHandler
+}
+
+func newPassiveDevicesObserver(c *Client, observerConfiguration ObserverConfig, discoveryConfiguration core.DiscoveryConfiguration, handler *devicesObservationHandler) *passiveDevicesObserver {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	obs := &passiveDevicesObserver{
+		c:                      c,
+		handler:                handler,
+		observerConfiguration:  observerConfiguration,
+		discoveryConfiguration: discoveryConfiguration,
+
+		cancel:        cancel,
+		wait:          wg.Wait,
+		done:          make(chan struct{}),
+		offline:       make(chan *presenceObservationHandler),
+		onlineDevices: make(map[string]*presenceObservationHandler),
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		obs.run(ctx)
+	}()
+	return obs
+}
+
+func (o *passiveDevicesObserver) run(ctx context.Context) {
+	defer close(o.done)
+	defer o.stopObservations()
+	t := time.NewTicker(o.observerConfiguration.PollingInterval)
+	defer t.Stop()
+	err := o.discoverNewDevices(ctx)
+	for err == nil {
+		select {
+		case <-ctx.Done():
+			o.handler.OnClose()
+			return
+		case h := <-o.offline:
+			err = o.handleOffline(ctx, h)
+		case <-t.C:
+			err = o.discoverNewDevices(ctx)
+		}
+	}
+	if ctx.Err() != nil {
+		o.handler.OnClose()
+		return
+	}
+	o.handler.Error(err)
+}
+
+// discoverNewDevices finds devices via multicast and by IP which are not online and starts observing their presence.
+func (o *passiveDevicesObserver) discoverNewDevices(ctx context.Context) error {
+	discoveryCtx, cancel := context.WithTimeout(ctx, o.observerConfiguration.PollingInterval)
+	defer cancel()
+	deviceIDs := coapSync.NewMap[string, struct{}]()
+	for deviceID := range o.c.GetAllDeviceIDsFoundByIP() {
+		deviceIDs.Store(deviceID, struct{}{})
+	}
+	err := o.c.client.GetDevicesByMulticast(discoveryCtx, o.discoveryConfiguration, &presenceDiscoveryHandler{
+		c:         o.c,
+		deviceIDs: deviceIDs,
+	})
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	observeCtx, cancelObserve := context.WithTimeout(ctx, o.observerConfiguration.PollingInterval)
+	defer cancelObserve()
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	newDevices := make([]*presenceObservationHandler, 0, 4)
+	deviceIDs.Range(func(deviceID string, _ struct{}) bool {
+		if _, ok := o.onlineDevices[deviceID]; ok {
+			return true
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h, errO := o.observePresence(observeCtx, deviceID)
+			if errO != nil {
+				o.c.logger.Debugf("cannot observe presence of device %v: %w", deviceID, errO)
+				return
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			newDevices = append(newDevices, h)
+		}()
+		return true
+	})
+	wg.Wait()
+
+	for _, h := range newDevices {
+		o.onlineDevices[h.deviceID] = h
+		if errE := o.emit(ctx, h.deviceID, DevicesObservationEvent_ONLINE); errE != nil {
+			return errE
+		}
+	}
+	return nil
+}
+
+func (o *passiveDevicesObserver) observePresence(ctx context.Context, deviceID string) (*presenceObservationHandler, error) {
+	h := &presenceObservationHandler{
+		observer: o,
+		deviceID: deviceID,
+	}
+	discovery := WithDiscoveryConfiguration(o.discoveryConfiguration)
+	observationID, err := o.c.ObserveResource(ctx, deviceID, device.ResourceURI, h, discovery)
+	if err != nil && ctx.Err() == nil {
+		// the device doesn't allow to observe /oic/d, so try /oic/res
+		observationID, err = o.c.ObserveResource(ctx, deviceID, resources.ResourceURI, h, discovery)
+	}
+	if err != nil {
+		return nil, err
+	}
+	h.observationID = observationID
+	return h, nil
+}
+
+func (o *passiveDevicesObserver) handleOffline(ctx context.Context, h *presenceObservationHandler) error {
+	if o.onlineDevices[h.deviceID] != h {
+		// the observation was replaced or the device was not reported as online
+		o.stopObservation(h)
+		return nil
+	}
+	delete(o.onlineDevices, h.deviceID)
+	o.stopObservation(h)
+	return o.emit(ctx, h.deviceID, DevicesObservationEvent_OFFLINE)
+}
+
+func (o *passiveDevicesObserver) stopObservation(h *presenceObservationHandler) {
+	if h.observationID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := o.c.StopObservingResource(ctx, h.observationID); err != nil {
+		o.c.logger.Debugf("cannot stop presence observation of device %v: %w", h.deviceID, err)
+	}
+}
+
+func (o *passiveDevicesObserver) stopObservations() {
+	for deviceID, h := range o.onlineDevices {
+		delete(o.onlineDevices, deviceID)
+		h.closed.Store(true)
+		o.stopObservation(h)
+	}
+}
+
+func (o *passiveDevicesObserver) emit(ctx context.Context, deviceID string, event DevicesObservationEvent_type) error {
+	return o.handler.Handle(ctx, DevicesObservationEvent{
+		DeviceID: deviceID,
+		Event:    event,
+	})
+}
+
+func (o *passiveDevicesObserver) Cancel() {
+	o.handler.close()
+	o.cancel()
+}
+
+func (o *passiveDevicesObserver) Wait() {
+	o.wait()
+}
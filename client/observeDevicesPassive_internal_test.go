@@ -0,0 +1,108 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/log"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingDevicesObservationHandler struct {
+	events []DevicesObservationEvent
+}
+
+func (h *recordingDevicesObservationHandler) Handle(_ context.Context, event DevicesObservationEvent) error {
+	h.events = append(h.events, event)
+	return nil
+}
+
+func (h *recordingDevicesObservationHandler) OnClose() {}
+
+func (h *recordingDevicesObservationHandler) Error(error) {}
+
+func TestWithPresenceStrategy(t *testing.T) {
+	var cfg observeDevicesOptions
+	require.Equal(t, PresenceStrategy_Polling, cfg.presenceStrategy)
+	cfg = WithPresenceStrategy(PresenceStrategy_Passive).applyOnObserveDevices(cfg)
+	require.Equal(t, PresenceStrategy_Passive, cfg.presenceStrategy)
+}
+
+func TestPassiveDevicesObserverHandleOffline(t *testing.T) {
+	handler := &recordingDevicesObservationHandler{}
+	o := &passiveDevicesObserver{
+		c: &Client{logger: log.NewNilLogger()},
+		handler: &devicesObservationHandler{
+			handler:            handler,
+			removeSubscription: func() {},
+		},
+		done:          make(chan struct{}),
+		offline:       make(chan *presenceObservationHandler),
+		onlineDevices: make(map[string]*presenceObservationHandler),
+	}
+	defer close(o.done)
+	h := &presenceObservationHandler{observer: o, deviceID: "1"}
+	o.onlineDevices[h.deviceID] = h
+
+	// the closed observation of a replaced presence observation doesn't emit the event
+	stale := &presenceObservationHandler{observer: o, deviceID: "1"}
+	require.NoError(t, o.handleOffline(context.Background(), stale))
+	require.Empty(t, handler.events)
+
+	// the close is reported only once
+	h.OnClose()
+	h.Error(context.Canceled)
+	require.NoError(t, o.handleOffline(context.Background(), <-o.offline))
+	require.Equal(t, []DevicesObservationEvent{{DeviceID: "1", Event: DevicesObservationEvent_OFFLINE}}, handler.events)
+	require.Empty(t, o.onlineDevices)
+	select {
+	case <-o.offline:
+		require.Fail(t, "unexpected offline notification")
+	default:
+	}
+}
+
+func TestPassiveDevicesObserverObservePresenceFailure(t *testing.T) {
+	c := &Client{
+		client:               core.NewClient(),
+		deviceCache:          NewDeviceCache(time.Minute, time.Second, log.NewNilLogger()),
+		observeResourceCache: coapSync.NewMap[string, *observationsHandler](),
+		logger:               log.NewNilLogger(),
+	}
+	defer func() {
+		require.NoError(t, c.Close(context.Background()))
+	}()
+	o := &passiveDevicesObserver{
+		c: c,
+		// the device cannot be discovered without discovery addresses
+		discoveryConfiguration: core.DiscoveryConfiguration{},
+	}
+
+	// the failed observation must not be reused by the retry
+	for range 2 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		_, err := o.observePresence(ctx, "00000000-0000-0000-0000-000000000001")
+		cancel()
+		require.Error(t, err)
+		require.Equal(t, 0, c.observeResourceCache.Length())
+	}
+}
@@ -74,6 +74,7 @@ type observationsHandler struct {
 
 	observationID string
 	lastMessage   atomic.Value
+	resilient     *resilientObservation
 
 	observations *coapSync.Map[string, *observationHandler]
 }
@@ -195,7 +196,12 @@ func (c *Client) ObserveResource(
 		return "", err
 	}
 
-	key := uuid.NewSHA1(uuid.NameSpaceURL, []byte(deviceID+href+"?if="+cfg.resourceInterface)).String()
+	keyData := deviceID + href + "?if=" + cfg.resourceInterface
+	if cfg.resilient != nil {
+		// resilient observations cannot share the observation with the non-resilient ones
+		keyData += "#resilient"
+	}
+	key := uuid.NewSHA1(uuid.NameSpaceURL, []byte(keyData)).String()
 	h, loaded := c.observeResourceCache.LoadOrStoreWithFunc(key, func(h *observationsHandler) *observationsHandler {
 		h.Lock()
 		return h
@@ -208,7 +214,6 @@ func (c *Client) ObserveResource(
 		h.Lock()
 		return &h
 	})
-	defer h.Unlock()
 	lastMessage := h.lastMessage.Load()
 	var firstMessage decodeFunc
 	if lastMessage != nil {
@@ -221,30 +226,58 @@ func (c *Client) ObserveResource(
 		firstMessage: firstMessage,
 	}
 	h.observations.Store(resourceObservationID.String(), &obsHandler)
+	if loaded && h.device == nil {
+		err = fmt.Errorf("cannot observe resource %v%v: observation was not established", deviceID, href)
+	} else if !loaded {
+		err = c.startObservingResource(ctx, h, deviceID, href, cfg)
+	}
+	if err != nil {
+		h.observations.Delete(resourceObservationID.String())
+		h.Unlock()
+		c.removeFailedObservation(h)
+		return "", err
+	}
+	h.Unlock()
 	if loaded {
 		go obsHandler.HandleFirstMessage()
-		return getObservationID(key, resourceObservationID.String()), nil
 	}
+	return getObservationID(key, resourceObservationID.String()), nil
+}
 
+func (c *Client) startObservingResource(ctx context.Context, h *observationsHandler, deviceID, href string, cfg observeOptions) error {
 	device, link, err := c.GetDeviceLinkForHref(ctx, deviceID, href, cfg.discoveryConfiguration, LinkNotFoundCallback{linkNotFoundCallback: cfg.linkNotFoundCallback})
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	if c.useDeviceIDInQuery {
 		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
 	}
+	if cfg.resilient != nil {
+		h.resilient = newResilientObservation(deviceID, href, cfg)
+	}
 
-	observationID, err = device.ObserveResourceWithCodec(ctx, link, observerCodec{contentFormat: cfg.codec.ContentFormat()}, h, cfg.opts...)
+	observationID, err := device.ObserveResourceWithCodec(ctx, link, observerCodec{contentFormat: cfg.codec.ContentFormat()}, h, cfg.opts...)
 	if err != nil {
-		return "", err
+		h.resilient = nil
+		return err
 	}
 
 	dev, _ := c.deviceCache.UpdateOrStoreDevice(device)
 	h.observationID = observationID
 	h.device = dev
+	return nil
+}
 
-	return getObservationID(key, resourceObservationID.String()), err
+// removeFailedObservation removes the handler of the observation which was not established from the cache,
+// so the next ObserveResource starts the observation again. It must be called without holding the lock of the handler.
+func (c *Client) removeFailedObservation(h *observationsHandler) {
+	c.observeResourceCache.ReplaceWithFunc(h.id, func(oldValue *observationsHandler, oldLoaded bool) (*observationsHandler, bool) {
+		if !oldLoaded || oldValue != h {
+			return oldValue, !oldLoaded
+		}
+		return oldValue, oldValue.observations.Length() == 0
+	})
 }
 
 // StopObservingResource method stops observing the resource of the device.
@@ -261,13 +294,16 @@ func (c *Client) StopObservingResource(ctx context.Context, observationID string
 			return nil, true
 		}
 		h := oldValue
-		resourceObservationID = h.observationID
 		_, ok := h.observations.LoadAndDelete(internalResourceObservationID)
 		if !ok {
 			return h, false
 		}
 
 		if h.observations.Length() == 0 {
+			if h.resilient != nil {
+				h.resilient.stop()
+			}
+			resourceObservationID = h.observationID
 			dev = h.device
 			return nil, true
 		}
@@ -305,9 +341,16 @@ func (o *observationsHandler) Handle(ctx context.Context, body coap.DecodeFunc)
 	var message *pool.Message
 	err := body(&message)
 	if err != nil {
-		o.Error(err)
+		o.closeWithError(err)
 		return
 	}
+	if o.resilient != nil {
+		skip := o.resilient.skipNotification(message)
+		o.resilient.storeETag(message)
+		if skip {
+			return
+		}
+	}
 	decode := createDecodeFunc(message)
 	o.lastMessage.Store(decode)
 	o.observations.Range(func(_ string, h *observationHandler) bool {
@@ -317,6 +360,9 @@ func (o *observationsHandler) Handle(ctx context.Context, body coap.DecodeFunc)
 }
 
 func (o *observationsHandler) OnClose() {
+	if o.startReconnect(nil) {
+		return
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	o.client.closeObservingResource(ctx, o)
@@ -326,6 +372,16 @@ func (o *observationsHandler) OnClose() {
 }
 
 func (o *observationsHandler) Error(err error) {
+	if o.startReconnect(err) {
+		return
+	}
+	o.closeWithError(err)
+}
+
+func (o *observationsHandler) closeWithError(err error) {
+	if o.resilient != nil {
+		o.resilient.stop()
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	o.client.closeObservingResource(ctx, o)
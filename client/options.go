@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/plgd-dev/device/v2/client/core"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
@@ -102,6 +103,13 @@ func WithPresharedKey(presharedKey []byte) OwnOption {
 	}
 }
 
+// WithSecurityProfile switches the device to the security profile (see sp.Profile_*) during the ownership transfer.
+func WithSecurityProfile(profile string) OwnOption {
+	return securityProfileOption{
+		profile: profile,
+	}
+}
+
 // WithOTMs allows to set ownership transfer methods, by default it is []OTMType{manufacturer}. For owning, the first match in order of OTMType with the device will be used.
 func WithOTMs(otmTypes []OTMType) OwnOption {
 	return otmOption{
@@ -116,6 +124,29 @@ func WithOTM(otmType OTMType) OwnOption {
 	}
 }
 
+// WithPayloadValidation validates the request payload against the schema from the introspection document
+// of the device before the request is sent. CreateResource validates the payload against the schema of
+// the create interface of the collection.
+func WithPayloadValidation() PayloadValidationOption {
+	return PayloadValidationOption{
+		validatePayload: true,
+	}
+}
+
+type PayloadValidationOption struct {
+	validatePayload bool
+}
+
+func (r PayloadValidationOption) applyOnUpdate(opts updateOptions) updateOptions {
+	opts.validatePayload = r.validatePayload
+	return opts
+}
+
+func (r PayloadValidationOption) applyOnCreate(opts createOptions) createOptions {
+	opts.validatePayload = r.validatePayload
+	return opts
+}
+
 type UseDeviceIDInQueryOption struct {
 	UseDeviceID bool
 }
@@ -125,6 +156,19 @@ func (r UseDeviceIDInQueryOption) applyOnGetDevices(opts getDevicesOptions) getD
 	return opts
 }
 
+type SecurityDomainDetailsOption struct{}
+
+func (r SecurityDomainDetailsOption) applyOnGetDevices(opts getDevicesOptions) getDevicesOptions {
+	opts.getSecurityDomain = true
+	return opts
+}
+
+// WithSecurityDomainDetails gets the security domain of the secured devices by GetDevicesDetails, it requires
+// an additional secure request to each secured device. The error is reported by DeviceDetails.SecurityDomainErr.
+func WithSecurityDomainDetails() SecurityDomainDetailsOption {
+	return SecurityDomainDetailsOption{}
+}
+
 type ResourceETagOption struct {
 	etag []byte
 }
@@ -229,6 +273,20 @@ func (r ResourceQueryOption) applyOnGetDeviceByIP(opts getDeviceByIPOptions) get
 	return opts
 }
 
+func (r ResourceQueryOption) applyOnReplaceACLs(opts replaceACLsOptions) replaceACLsOptions {
+	if r.resourceQuery != "" {
+		opts.opts = append(opts.opts, coap.WithQuery(r.resourceQuery))
+	}
+	return opts
+}
+
+func (r ResourceQueryOption) applyOnScanNetwork(opts scanNetworkOptions) scanNetworkOptions {
+	if r.resourceQuery != "" {
+		opts.opts = append(opts.opts, coap.WithQuery(r.resourceQuery))
+	}
+	return opts
+}
+
 type DiscoveryConfigurationOption struct {
 	cfg core.DiscoveryConfiguration
 }
@@ -273,6 +331,11 @@ func (r DiscoveryConfigurationOption) applyOnCommonCommand(opts commonCommandOpt
 	return opts
 }
 
+func (r DiscoveryConfigurationOption) applyOnReplaceACLs(opts replaceACLsOptions) replaceACLsOptions {
+	opts.discoveryConfiguration = r.cfg
+	return opts
+}
+
 func (r DiscoveryConfigurationOption) applyOnObserve(opts observeOptions) observeOptions {
 	opts.discoveryConfiguration = r.cfg
 	return opts
@@ -352,6 +415,7 @@ type updateOptions struct {
 	codec                  coap.Codec
 	discoveryConfiguration core.DiscoveryConfiguration
 	linkNotFoundCallback   func(links schema.ResourceLinks, href string) (schema.ResourceLink, error)
+	validatePayload        bool
 }
 
 type createOptions struct {
@@ -359,6 +423,7 @@ type createOptions struct {
 	codec                  coap.Codec
 	discoveryConfiguration core.DiscoveryConfiguration
 	linkNotFoundCallback   func(links schema.ResourceLinks, href string) (schema.ResourceLink, error)
+	validatePayload        bool
 }
 
 type deleteOptions struct {
@@ -427,11 +492,17 @@ func (r GetDetailsOption) applyOnGetDeviceByIP(opts getDeviceByIPOptions) getDev
 	return opts
 }
 
+func (r GetDetailsOption) applyOnScanNetwork(opts scanNetworkOptions) scanNetworkOptions {
+	opts.getDetails = r.getDetails
+	return opts
+}
+
 type getDevicesOptions struct {
 	resourceTypes          []string
 	getDetails             GetDetailsFunc
 	discoveryConfiguration core.DiscoveryConfiguration
 	useDeviceID            bool
+	getSecurityDomain      bool
 }
 
 type getDeviceOptions struct {
@@ -449,8 +520,74 @@ type getDevicesWithHandlerOptions struct {
 	discoveryConfiguration core.DiscoveryConfiguration
 }
 
+type scanNetworkOptions struct {
+	getDetails  GetDetailsFunc
+	opts        []coap.OptionFunc
+	concurrency int
+	hostTimeout time.Duration
+}
+
+// ScanNetworkOption option definition.
+type ScanNetworkOption = interface {
+	applyOnScanNetwork(opts scanNetworkOptions) scanNetworkOptions
+}
+
+type ScanConcurrencyOption struct {
+	concurrency int
+}
+
+func (r ScanConcurrencyOption) applyOnScanNetwork(opts scanNetworkOptions) scanNetworkOptions {
+	if r.concurrency > 0 {
+		opts.concurrency = r.concurrency
+	}
+	return opts
+}
+
+// WithScanConcurrency sets the maximal number of the hosts probed at once by ScanNetwork, default is 64.
+func WithScanConcurrency(concurrency int) ScanConcurrencyOption {
+	return ScanConcurrencyOption{
+		concurrency: concurrency,
+	}
+}
+
+type ScanHostTimeoutOption struct {
+	timeout time.Duration
+}
+
+func (r ScanHostTimeoutOption) applyOnScanNetwork(opts scanNetworkOptions) scanNetworkOptions {
+	if r.timeout > 0 {
+		opts.hostTimeout = r.timeout
+	}
+	return opts
+}
+
+// WithScanHostTimeout limits the duration of probing of one host by ScanNetwork including getting
+// the details of the found devices, default is 2s.
+func WithScanHostTimeout(timeout time.Duration) ScanHostTimeoutOption {
+	return ScanHostTimeoutOption{
+		timeout: timeout,
+	}
+}
+
 type observeDevicesOptions struct {
 	discoveryConfiguration core.DiscoveryConfiguration
+	presenceStrategy       PresenceStrategy
+}
+
+type PresenceStrategyOption struct {
+	strategy PresenceStrategy
+}
+
+func (r PresenceStrategyOption) applyOnObserveDevices(opts observeDevicesOptions) observeDevicesOptions {
+	opts.presenceStrategy = r.strategy
+	return opts
+}
+
+// WithPresenceStrategy sets how ObserveDevices detects that the device is online or offline, default is PresenceStrategy_Polling.
+func WithPresenceStrategy(strategy PresenceStrategy) PresenceStrategyOption {
+	return PresenceStrategyOption{
+		strategy: strategy,
+	}
 }
 
 type ResourceTypesOption struct {
@@ -504,6 +641,7 @@ type observeOptions struct {
 	resourceInterface      string
 	discoveryConfiguration core.DiscoveryConfiguration
 	linkNotFoundCallback   func(links schema.ResourceLinks, href string) (schema.ResourceLink, error)
+	resilient              *ResilientObservationConfig
 }
 
 // ObserveOption option definition.
@@ -516,6 +654,7 @@ type OTMType int
 const (
 	OTMType_Manufacturer OTMType = 0
 	OTMType_JustWorks    OTMType = 1
+	OTMType_RandomPin    OTMType = 2
 )
 
 type ownOptions struct {
@@ -556,6 +695,15 @@ func (r actionAfterOwnOption) applyOnOwn(opts ownOptions) ownOptions {
 	return opts
 }
 
+type securityProfileOption struct {
+	profile string
+}
+
+func (r securityProfileOption) applyOnOwn(opts ownOptions) ownOptions {
+	opts.opts = append(opts.opts, core.WithSecurityProfile(r.profile))
+	return opts
+}
+
 type otmOption struct {
 	otmTypes []OTMType
 }
@@ -589,3 +737,26 @@ func (r commonCommandOptions) applyOnGetDevice(opts getDeviceOptions) getDeviceO
 	opts.discoveryConfiguration = r.discoveryConfiguration
 	return opts
 }
+
+type replaceACLsOptions struct {
+	commonCommandOptions
+	replaceOwnerACLs bool
+}
+
+// ReplaceACLsOption option definition.
+type ReplaceACLsOption = interface {
+	applyOnReplaceACLs(opts replaceACLsOptions) replaceACLsOptions
+}
+
+type ReplaceOwnerACLsOption struct{}
+
+func (r ReplaceOwnerACLsOption) applyOnReplaceACLs(opts replaceACLsOptions) replaceACLsOptions {
+	opts.replaceOwnerACLs = true
+	return opts
+}
+
+// WithReplaceOwnerACLs allows ReplaceACLs to remove the access control entries of the owner and the entries
+// which allow the anonymous discovery of the device, by default they are kept.
+func WithReplaceOwnerACLs() ReplaceOwnerACLsOption {
+	return ReplaceOwnerACLsOption{}
+}
@@ -354,6 +354,7 @@ func TestApplyOnGetDevices(t *testing.T) {
 		WithGetDetails(getDetails),
 		WithResourceTypes(testRt),
 		WithUseDeviceID(true),
+		WithSecurityDomainDetails(),
 	}
 
 	var o getDevicesOptions
@@ -369,6 +370,8 @@ func TestApplyOnGetDevices(t *testing.T) {
 	require.Contains(t, o.resourceTypes, testRt)
 	// WithUseDeviceID
 	require.True(t, o.useDeviceID)
+	// WithSecurityDomainDetails
+	require.True(t, o.getSecurityDomain)
 }
 
 func TestApplyOnGetGetDevicesWithHandler(t *testing.T) {
@@ -30,6 +30,9 @@ func (c *Client) OwnDevice(ctx context.Context, deviceID string, opts ...OwnOpti
 		otmTypes:               []OTMType{OTMType_JustWorks},
 		discoveryConfiguration: core.DefaultDiscoveryConfiguration(),
 	}
+	if c.securityDomain != nil {
+		cfg.opts = append(cfg.opts, core.WithSecurityDomain(*c.securityDomain))
+	}
 	for _, o := range opts {
 		cfg = o.applyOnOwn(cfg)
 	}
@@ -87,6 +90,9 @@ func (c *Client) ownDeviceWithSigners(ctx context.Context, deviceID string, otmC
 		return "", err
 	}
 	c.updateCache(d, deviceID)
+	if ownerID, errID := d.GetSdkOwnerID(); errID == nil {
+		c.deviceCache.SetDeviceOwnerID(d.DeviceID(), ownerID)
+	}
 
 	return d.DeviceID(), nil
 }
@@ -0,0 +1,255 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/backoff"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+)
+
+// ResilientObservationConfig configures the re-establishing of the observation after the connection to the device is lost.
+type ResilientObservationConfig struct {
+	// InitialInterval is the delay before the first attempt, the delay doubles with each failed attempt. Default: 1s.
+	InitialInterval time.Duration
+	// MaxInterval limits the delay between the attempts. Default: 1m.
+	MaxInterval time.Duration
+	// MaxAttempts is the number of failed attempts after which the observation is closed by Error, 0 means unlimited.
+	MaxAttempts int
+	// AttemptTimeout limits the duration of one attempt, including the discovery of the device. Default: 10s.
+	AttemptTimeout time.Duration
+}
+
+func (cfg ResilientObservationConfig) normalize() ResilientObservationConfig {
+	if cfg.InitialInterval <= 0 {
+		cfg.InitialInterval = time.Second
+	}
+	if cfg.MaxInterval <= 0 {
+		cfg.MaxInterval = time.Minute
+	}
+	if cfg.MaxInterval < cfg.InitialInterval {
+		cfg.MaxInterval = cfg.InitialInterval
+	}
+	if cfg.AttemptTimeout <= 0 {
+		cfg.AttemptTimeout = time.Second * 10
+	}
+	return cfg
+}
+
+// delay returns the randomized delay before the attempt.
+func (cfg ResilientObservationConfig) delay(attempt int) time.Duration {
+	return backoff.Delay(cfg.InitialInterval, cfg.MaxInterval, attempt)
+}
+
+type ResilientObservationOption struct {
+	cfg ResilientObservationConfig
+}
+
+func (r ResilientObservationOption) applyOnObserve(opts observeOptions) observeOptions {
+	cfg := r.cfg.normalize()
+	opts.resilient = &cfg
+	return opts
+}
+
+// WithResilientObservation re-establishes the observation when the connection to the device is lost. The device is
+// found again via the device cache or multicast and the last ETag is sent with the new observation, so the unchanged
+// representation is not notified again. Handlers implementing ObservationReconnectHandler are notified about the reconnects.
+func WithResilientObservation(cfg ResilientObservationConfig) ResilientObservationOption {
+	return ResilientObservationOption{
+		cfg: cfg,
+	}
+}
+
+type ObservationReconnectState int
+
+const (
+	// ObservationReconnectState_Disconnected is reported when the observation is lost, Err contains the reason if known.
+	ObservationReconnectState_Disconnected ObservationReconnectState = iota
+	// ObservationReconnectState_Failed is reported when the attempt fails and the next attempt is scheduled.
+	ObservationReconnectState_Failed
+	// ObservationReconnectState_Reconnected is reported when the observation is re-established.
+	ObservationReconnectState_Reconnected
+)
+
+type ObservationReconnectEvent struct {
+	State ObservationReconnectState
+	// Attempt is the number of the attempt, it is 0 for ObservationReconnectState_Disconnected.
+	Attempt int
+	Err     error
+}
+
+// ObservationReconnectHandler is an optional interface of the observation handler of resilient observations.
+type ObservationReconnectHandler interface {
+	OnReconnect(event ObservationReconnectEvent)
+}
+
+type resilientObservation struct {
+	cfg      ResilientObservationConfig
+	deviceID string
+	href     string
+	opts     observeOptions
+
+	lastETag     atomic.Pointer[[]byte]
+	resubscribed atomic.Bool
+	reconnecting atomic.Bool
+	stopped      atomic.Bool
+	stopOnce     sync.Once
+	done         chan struct{}
+}
+
+func newResilientObservation(deviceID, href string, opts observeOptions) *resilientObservation {
+	return &resilientObservation{
+		cfg:      *opts.resilient,
+		deviceID: deviceID,
+		href:     href,
+		opts:     opts,
+		done:     make(chan struct{}),
+	}
+}
+
+func (r *resilientObservation) stop() {
+	r.stopOnce.Do(func() {
+		r.stopped.Store(true)
+		close(r.done)
+	})
+}
+
+func (r *resilientObservation) getLastETag() []byte {
+	etag := r.lastETag.Load()
+	if etag == nil {
+		return nil
+	}
+	return *etag
+}
+
+// skipNotification returns true for the first notification after the observation was re-established when the
+// representation of the resource is unchanged.
+func (r *resilientObservation) skipNotification(message *pool.Message) bool {
+	lastETag := r.getLastETag()
+	if !r.resubscribed.Swap(false) || lastETag == nil {
+		return false
+	}
+	if message.Code() == codes.Valid {
+		return true
+	}
+	etag, err := message.ETag()
+	return err == nil && bytes.Equal(etag, lastETag)
+}
+
+func (r *resilientObservation) storeETag(message *pool.Message) {
+	if message.Code() != codes.Content {
+		return
+	}
+	etag, err := message.ETag()
+	if err != nil {
+		r.lastETag.Store(nil)
+		return
+	}
+	etag = slices.Clone(etag)
+	r.lastETag.Store(&etag)
+}
+
+func (o *observationsHandler) notifyReconnect(event ObservationReconnectEvent) {
+	o.observations.Range(func(_ string, h *observationHandler) bool {
+		if rh, ok := h.handler.(ObservationReconnectHandler); ok {
+			rh.OnReconnect(event)
+		}
+		return true
+	})
+}
+
+// startReconnect starts re-establishing of the observation, it returns false when the observation is not resilient
+// or it was stopped.
+func (o *observationsHandler) startReconnect(cause error) bool {
+	r := o.resilient
+	if r == nil || r.stopped.Load() || o.client.deviceCache.closed.Load() {
+		return false
+	}
+	if !r.reconnecting.CompareAndSwap(false, true) {
+		return true
+	}
+	go o.reconnect(cause)
+	return true
+}
+
+func (o *observationsHandler) reconnect(cause error) {
+	r := o.resilient
+	defer r.reconnecting.Store(false)
+	o.notifyReconnect(ObservationReconnectEvent{State: ObservationReconnectState_Disconnected, Err: cause})
+	for attempt := 1; ; attempt++ {
+		t := time.NewTimer(r.cfg.delay(attempt))
+		select {
+		case <-t.C:
+		case <-r.done:
+			t.Stop()
+			return
+		}
+		err := o.resubscribe()
+		if err == nil {
+			o.notifyReconnect(ObservationReconnectEvent{State: ObservationReconnectState_Reconnected, Attempt: attempt})
+			return
+		}
+		if r.stopped.Load() {
+			return
+		}
+		if r.cfg.MaxAttempts > 0 && attempt >= r.cfg.MaxAttempts {
+			r.stop()
+			o.closeWithError(err)
+			return
+		}
+		o.client.logger.Debugf("cannot re-establish observation of %v%v: %w", r.deviceID, r.href, err)
+		o.notifyReconnect(ObservationReconnectEvent{State: ObservationReconnectState_Failed, Attempt: attempt, Err: err})
+	}
+}
+
+func (o *observationsHandler) resubscribe() error {
+	r := o.resilient
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.AttemptTimeout)
+	defer cancel()
+	device, link, err := o.client.GetDeviceLinkForHref(ctx, r.deviceID, r.href, r.opts.discoveryConfiguration, LinkNotFoundCallback{linkNotFoundCallback: r.opts.linkNotFoundCallback})
+	if err != nil {
+		return err
+	}
+	opts := r.opts.opts
+	if etag := r.getLastETag(); etag != nil {
+		opts = append(slices.Clone(opts), coap.WithETag(etag))
+	}
+	r.resubscribed.Store(true)
+	observationID, err := device.ObserveResourceWithCodec(ctx, link, observerCodec{contentFormat: r.opts.codec.ContentFormat()}, o, opts...)
+	if err != nil {
+		r.resubscribed.Store(false)
+		return err
+	}
+	dev, _ := o.client.deviceCache.UpdateOrStoreDevice(device)
+	o.Lock()
+	o.observationID = observationID
+	o.device = dev
+	o.Unlock()
+	if r.stopped.Load() {
+		// observation was stopped during the reconnect
+		_, _ = dev.StopObservingResource(ctx, observationID)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/go-coap/v3/message"
+	"github.com/plgd-dev/go-coap/v3/message/codes"
+	"github.com/plgd-dev/go-coap/v3/message/pool"
+	coapSync "github.com/plgd-dev/go-coap/v3/pkg/sync"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResilientObservationConfigDelay(t *testing.T) {
+	cfg := ResilientObservationConfig{}.normalize()
+	require.Equal(t, time.Second, cfg.InitialInterval)
+	require.Equal(t, time.Minute, cfg.MaxInterval)
+	require.Equal(t, time.Second*10, cfg.AttemptTimeout)
+
+	cfg = ResilientObservationConfig{
+		InitialInterval: time.Second,
+		MaxInterval:     time.Second * 8,
+	}.normalize()
+	for attempt, maxDelay := range map[int]time.Duration{
+		1:  time.Second,
+		2:  time.Second * 2,
+		3:  time.Second * 4,
+		4:  time.Second * 8,
+		10: time.Second * 8,
+	} {
+		for range 10 {
+			d := cfg.delay(attempt)
+			require.GreaterOrEqual(t, d, maxDelay/2)
+			require.LessOrEqual(t, d, maxDelay)
+		}
+	}
+}
+
+type countingObservationHandler struct {
+	notifications int
+}
+
+func (h *countingObservationHandler) Handle(context.Context, func(v interface{}) error) {
+	h.notifications++
+}
+
+func (h *countingObservationHandler) OnClose() {}
+
+func (h *countingObservationHandler) Error(error) {}
+
+func newTestNotification(t *testing.T, code codes.Code, etag []byte) *pool.Message {
+	m := pool.NewMessage(context.Background())
+	m.SetCode(code)
+	if code == codes.Content {
+		m.SetContentFormat(message.AppOcfCbor)
+		m.SetBody(bytes.NewReader([]byte{0xa0}))
+	}
+	if etag != nil {
+		require.NoError(t, m.SetETag(etag))
+	}
+	return m
+}
+
+func TestResilientObservationSkipsUnchangedNotification(t *testing.T) {
+	handler := &countingObservationHandler{}
+	h := &observationsHandler{
+		observations: coapSync.NewMap[string, *observationHandler](),
+		resilient: newResilientObservation("deviceID", "/href", observeOptions{
+			resilient: &ResilientObservationConfig{},
+		}),
+	}
+	h.observations.Store("1", &observationHandler{handler: handler, codec: observerCodec{}})
+	notify := func(m *pool.Message) {
+		h.Handle(context.Background(), func(v interface{}) error {
+			return observerCodec{}.Decode(m, v)
+		})
+	}
+
+	notify(newTestNotification(t, codes.Content, []byte{1}))
+	require.Equal(t, 1, handler.notifications)
+	require.Equal(t, []byte{1}, h.resilient.getLastETag())
+
+	// the device confirms that the representation is unchanged
+	h.resilient.resubscribed.Store(true)
+	notify(newTestNotification(t, codes.Valid, []byte{1}))
+	require.Equal(t, 1, handler.notifications)
+
+	// the device sends the same representation again
+	h.resilient.resubscribed.Store(true)
+	notify(newTestNotification(t, codes.Content, []byte{1}))
+	require.Equal(t, 1, handler.notifications)
+	notify(newTestNotification(t, codes.Content, []byte{1}))
+	require.Equal(t, 2, handler.notifications)
+
+	// the representation has changed during the reconnect
+	h.resilient.resubscribed.Store(true)
+	notify(newTestNotification(t, codes.Content, []byte{2}))
+	require.Equal(t, 3, handler.notifications)
+	require.Equal(t, []byte{2}, h.resilient.getLastETag())
+}
+
+func TestResilientObservationStopped(t *testing.T) {
+	h := &observationsHandler{
+		client:       &Client{deviceCache: &DeviceCache{}},
+		observations: coapSync.NewMap[string, *observationHandler](),
+		resilient: newResilientObservation("deviceID", "/href", observeOptions{
+			resilient: &ResilientObservationConfig{},
+		}),
+	}
+	h.resilient.stop()
+	h.resilient.stop()
+	require.False(t, h.startReconnect(nil))
+}
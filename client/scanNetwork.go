@@ -0,0 +1,158 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxScanNetworkHosts limits the number of the hosts scanned by ScanNetwork, e.g. to avoid scanning of the IPv6 /64 network.
+const maxScanNetworkHosts = 1 << 16
+
+// ScanNetworkHandler receives the devices found by ScanNetwork, the methods are called concurrently.
+type ScanNetworkHandler interface {
+	Handle(ctx context.Context, device DeviceDetails)
+	// Error gets errors of the found devices, the hosts without a device are not reported.
+	Error(err error)
+}
+
+// parseScanNetwork parses the comma separated list of CIDR ranges and IP addresses.
+func parseScanNetwork(cidr string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	hosts := 0
+	for _, v := range strings.Split(cidr, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		var prefix netip.Prefix
+		if strings.Contains(v, "/") {
+			p, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %v: %w", v, err)
+			}
+			prefix = p.Masked()
+		} else {
+			addr, err := netip.ParseAddr(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IP address %v: %w", v, err)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		hostBits := prefix.Addr().BitLen() - prefix.Bits()
+		if hostBits >= 17 {
+			return nil, fmt.Errorf("invalid CIDR %v: too many hosts, maximum is %v", v, maxScanNetworkHosts)
+		}
+		hosts += 1 << hostBits
+		if hosts > maxScanNetworkHosts {
+			return nil, fmt.Errorf("invalid CIDR %v: too many hosts, maximum is %v", cidr, maxScanNetworkHosts)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	if len(prefixes) == 0 {
+		return nil, errors.New("invalid CIDR: empty")
+	}
+	return prefixes, nil
+}
+
+// rangeHosts calls f for every host of the prefix. The network and broadcast addresses of IPv4 networks
+// with more than two addresses are skipped.
+func rangeHosts(prefix netip.Prefix, f func(addr netip.Addr) bool) bool {
+	addr := prefix.Addr()
+	skipEdges := addr.Is4() && prefix.Bits() < 31
+	for ; addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		if skipEdges && (addr == prefix.Addr() || !prefix.Contains(addr.Next())) {
+			continue
+		}
+		if !f(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// ScanNetwork probes every host of the comma separated list of CIDR ranges and IP addresses
+// (e.g. "192.168.1.0/24,fd00::10,fd00::11") via unicast, so it finds devices in networks without multicast.
+// The found devices are stored to the cache without expiration in the same way as by GetDevicesByIP and their
+// details are sent to the handler. The hosts are probed concurrently, see WithScanConcurrency and WithScanHostTimeout.
+func (c *Client) ScanNetwork(ctx context.Context, cidr string, handler ScanNetworkHandler, opts ...ScanNetworkOption) error {
+	cfg := scanNetworkOptions{
+		getDetails:  getDetails,
+		concurrency: 64,
+		hostTimeout: time.Second * 2,
+	}
+	for _, o := range opts {
+		cfg = o.applyOnScanNetwork(cfg)
+	}
+	prefixes, err := parseScanNetwork(cidr)
+	if err != nil {
+		return err
+	}
+
+	hosts := make(chan netip.Addr)
+	var wg sync.WaitGroup
+	wg.Add(cfg.concurrency)
+	for range cfg.concurrency {
+		go func() {
+			defer wg.Done()
+			for addr := range hosts {
+				c.scanHost(ctx, addr, handler, cfg)
+			}
+		}()
+	}
+	for _, prefix := range prefixes {
+		ok := rangeHosts(prefix, func(addr netip.Addr) bool {
+			select {
+			case hosts <- addr:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if !ok {
+			break
+		}
+	}
+	close(hosts)
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (c *Client) scanHost(ctx context.Context, addr netip.Addr, handler ScanNetworkHandler, cfg scanNetworkOptions) {
+	hostCtx, cancel := context.WithTimeout(ctx, cfg.hostTimeout)
+	defer cancel()
+	ip := addr.String()
+	devs, err := c.GetDevicesByIP(hostCtx, ip)
+	if err != nil {
+		// there is no device on the host
+		return
+	}
+	for _, d := range devs {
+		details, err := c.getDeviceDetails(hostCtx, d.Device, d.Links, cfg.getDetails, cfg.opts)
+		if err != nil {
+			handler.Error(fmt.Errorf("cannot get details of device %v found by ip %v: %w", d.Device.DeviceID(), ip, err))
+			continue
+		}
+		handler.Handle(ctx, details)
+	}
+}
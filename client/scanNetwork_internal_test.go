@@ -0,0 +1,70 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func scanNetworkHosts(t *testing.T, cidr string) []string {
+	prefixes, err := parseScanNetwork(cidr)
+	require.NoError(t, err)
+	var hosts []string
+	for _, p := range prefixes {
+		rangeHosts(p, func(addr netip.Addr) bool {
+			hosts = append(hosts, addr.String())
+			return true
+		})
+	}
+	return hosts
+}
+
+func TestParseScanNetwork(t *testing.T) {
+	require.Equal(t, []string{"192.168.1.1", "192.168.1.2"}, scanNetworkHosts(t, "192.168.1.3/30"))
+	require.Equal(t, []string{"10.0.0.0", "10.0.0.1"}, scanNetworkHosts(t, "10.0.0.0/31"))
+	require.Equal(t, []string{"10.0.0.5", "fd00::10", "fd00::11", "fd00::20"}, scanNetworkHosts(t, "10.0.0.5, fd00::10/127,fd00::20"))
+	require.Len(t, scanNetworkHosts(t, "172.16.0.0/16"), 1<<16-2)
+
+	for _, cidr := range []string{"", " , ", "10.0.0.0/33", "invalid", "10.0.0.0/8", "fd00::/64", "10.0.0.0/16,10.1.0.0/16"} {
+		_, err := parseScanNetwork(cidr)
+		require.Error(t, err, cidr)
+	}
+}
+
+func TestRangeHostsStop(t *testing.T) {
+	prefixes, err := parseScanNetwork("10.0.0.0/24")
+	require.NoError(t, err)
+	var n int
+	require.False(t, rangeHosts(prefixes[0], func(netip.Addr) bool {
+		n++
+		return n < 3
+	}))
+	require.Equal(t, 3, n)
+}
+
+func TestScanNetworkOptions(t *testing.T) {
+	var cfg scanNetworkOptions
+	for _, o := range []ScanNetworkOption{WithQuery("if=oic.if.baseline"), WithScanConcurrency(8), WithScanHostTimeout(0)} {
+		cfg = o.applyOnScanNetwork(cfg)
+	}
+	require.Len(t, cfg.opts, 1)
+	require.Equal(t, 8, cfg.concurrency)
+	require.Zero(t, cfg.hostTimeout)
+}
@@ -0,0 +1,45 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/schema/sdi"
+)
+
+// SetSecurityDomain sets the security domain information of the device.
+func (c *Client) SetSecurityDomain(ctx context.Context, deviceID string, securityDomain sdi.SDI, opts ...CommonCommandOption) error {
+	return c.provisionDevice(ctx, deviceID, opts, func(ctx context.Context, p *core.ProvisioningClient) error {
+		return p.SetSecurityDomain(ctx, securityDomain)
+	})
+}
+
+// GroupDevicesBySecurityDomain groups the devices returned by GetDevicesDetails with WithSecurityDomainDetails by the uuid of the security domain.
+// Devices without the security domain information are grouped under the empty key.
+func GroupDevicesBySecurityDomain(devices map[string]DeviceDetails) map[string][]DeviceDetails {
+	groups := make(map[string][]DeviceDetails)
+	for _, d := range devices {
+		var id string
+		if d.SecurityDomain != nil {
+			id = d.SecurityDomain.UUID
+		}
+		groups[id] = append(groups[id], d)
+	}
+	return groups
+}
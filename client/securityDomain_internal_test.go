@@ -0,0 +1,68 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/schema/doxm"
+	"github.com/plgd-dev/device/v2/schema/sdi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupDevicesBySecurityDomain(t *testing.T) {
+	ownerID := uuid.NewString()
+	domain := sdi.SDI{
+		UUID: uuid.NewString(),
+		Name: "site",
+	}
+	devs := map[string]DeviceDetails{
+		"a": {ID: "a"},
+		"b": {ID: "b"},
+		"c": {ID: "c"},
+	}
+	devs = setOwnership(ownerID, devs, map[string]ownership{
+		"a": {
+			doxm: &doxm.Doxm{OwnerID: ownerID},
+		},
+		"b": {
+			doxm: &doxm.Doxm{OwnerID: uuid.NewString()},
+		},
+		"c": {
+			status: OwnershipStatus_OwnedByOther,
+		},
+	})
+	sdErr := errors.New("access denied")
+	devs = setSecurityDomains(devs, map[string]securityDomain{
+		"a": {sdi: &domain},
+		"b": {sdi: &domain},
+		"c": {err: sdErr},
+	})
+	require.Equal(t, &domain, devs["a"].SecurityDomain)
+	// the security domain is read from the device owned by other
+	require.Equal(t, &domain, devs["b"].SecurityDomain)
+	require.Nil(t, devs["c"].SecurityDomain)
+	require.ErrorIs(t, devs["c"].SecurityDomainErr, sdErr)
+
+	groups := GroupDevicesBySecurityDomain(devs)
+	require.Len(t, groups, 2)
+	require.Len(t, groups[domain.UUID], 2)
+	require.Len(t, groups[""], 1)
+	require.Equal(t, "c", groups[""][0].ID)
+}
@@ -0,0 +1,57 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/schema/sp"
+)
+
+// GetSecurityProfile returns the supported and the current security profiles of the device.
+func (c *Client) GetSecurityProfile(ctx context.Context, deviceID string, opts ...CommonCommandOption) (sp.SecurityProfile, error) {
+	var resp sp.SecurityProfile
+	err := c.provisionDevice(ctx, deviceID, opts, func(ctx context.Context, p *core.ProvisioningClient) error {
+		var err error
+		resp, err = p.GetSecurityProfile(ctx)
+		return err
+	})
+	if err != nil {
+		return sp.SecurityProfile{}, err
+	}
+	return resp, nil
+}
+
+// SetSecurityProfile switches the device to the security profile (see sp.Profile_*), the profile
+// must be one of the supported profiles of the device.
+func (c *Client) SetSecurityProfile(ctx context.Context, deviceID string, profile string, opts ...CommonCommandOption) error {
+	return c.provisionDevice(ctx, deviceID, opts, func(ctx context.Context, p *core.ProvisioningClient) error {
+		current, err := p.GetSecurityProfile(ctx)
+		if err != nil {
+			return err
+		}
+		if current.CurrentProfile == profile {
+			return nil
+		}
+		if !current.IsSupported(profile) {
+			return core.MakeInvalidArgument(fmt.Errorf("security profile %v is not supported by the device: %v", profile, current.SupportedProfiles))
+		}
+		return p.SetSecurityProfile(ctx, profile)
+	})
+}
@@ -0,0 +1,330 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/plgd-dev/device/v2/client/core"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema"
+	"github.com/plgd-dev/device/v2/schema/softwareupdate"
+)
+
+type SoftwareUpdateEvent_type uint8
+
+const (
+	// SoftwareUpdateEvent_STATE_CHANGED is emitted for the first notification and for every change of swupdatestate.
+	SoftwareUpdateEvent_STATE_CHANGED SoftwareUpdateEvent_type = 0
+	// SoftwareUpdateEvent_FINISHED is emitted when the device reports the swupdateresult of the update.
+	SoftwareUpdateEvent_FINISHED SoftwareUpdateEvent_type = 1
+	// SoftwareUpdateEvent_TIMEOUT is emitted when the update doesn't finish in time.
+	SoftwareUpdateEvent_TIMEOUT SoftwareUpdateEvent_type = 2
+)
+
+type SoftwareUpdateEvent struct {
+	DeviceID      string
+	Event         SoftwareUpdateEvent_type
+	PreviousState softwareupdate.UpdateState
+	State         softwareupdate.UpdateState
+	// Result is the swupdateresult reported by the device, -1 when it is not set.
+	Result   int
+	Resource softwareupdate.SoftwareUpdate
+}
+
+// SoftwareUpdateObservationHandler receives events of WatchSoftwareUpdate. After the FINISHED or TIMEOUT event
+// the watch is stopped and OnClose is called.
+type SoftwareUpdateObservationHandler = interface {
+	Handle(ctx context.Context, event SoftwareUpdateEvent)
+	OnClose()
+	Error(err error)
+}
+
+func (c *Client) getSoftwareUpdateLink(ctx context.Context, deviceID string, cfg commonCommandOptions) (*core.Device, schema.ResourceLink, error) {
+	d, links, err := c.GetDevice(ctx, deviceID, WithDiscoveryConfiguration(cfg.discoveryConfiguration))
+	if err != nil {
+		return nil, schema.ResourceLink{}, err
+	}
+	links = links.GetResourceLinks(softwareupdate.ResourceType)
+	if len(links) == 0 {
+		return nil, schema.ResourceLink{}, core.MakeUnavailable(fmt.Errorf("cannot find '%v' in device %v", softwareupdate.ResourceType, deviceID))
+	}
+	return d, links[0], nil
+}
+
+func (c *Client) updateSoftwareUpdate(ctx context.Context, deviceID string, req softwareupdate.UpdateRequest, opts ...CommonCommandOption) (softwareupdate.SoftwareUpdate, error) {
+	cfg := applyCommonOptions(opts...)
+	d, link, err := c.getSoftwareUpdateLink(ctx, deviceID, cfg)
+	if err != nil {
+		return softwareupdate.SoftwareUpdate{}, err
+	}
+	if c.useDeviceIDInQuery {
+		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
+	}
+	var resp softwareupdate.SoftwareUpdate
+	err = d.UpdateResource(ctx, link, req, &resp, cfg.opts...)
+	if err != nil {
+		return softwareupdate.SoftwareUpdate{}, err
+	}
+	if resp.GetUpdateResult() == softwareupdate.UpdateResult_SUCCESS {
+		c.evictIntrospection(deviceID)
+	}
+	return resp, nil
+}
+
+// CheckSoftwareUpdate asks the device to check whether a new software is available and returns the state of the software update resource.
+func (c *Client) CheckSoftwareUpdate(ctx context.Context, deviceID string, opts ...CommonCommandOption) (softwareupdate.SoftwareUpdate, error) {
+	return c.updateSoftwareUpdate(ctx, deviceID, softwareupdate.UpdateRequest{
+		UpdateAction: softwareupdate.UpdateAction_CHECK_IS_AVAILABLE,
+	}, opts...)
+}
+
+// StartSoftwareUpdate schedules the software update action at the device. The zero updateTime means now.
+// Use WatchSoftwareUpdate to follow the progress of the update.
+func (c *Client) StartSoftwareUpdate(ctx context.Context, deviceID, purl string, action softwareupdate.UpdateAction, updateTime time.Time, opts ...CommonCommandOption) error {
+	if updateTime.IsZero() {
+		updateTime = time.Now()
+	}
+	_, err := c.updateSoftwareUpdate(ctx, deviceID, softwareupdate.UpdateRequest{
+		PackageURL:   purl,
+		UpdateAction: action,
+		UpdateTime:   updateTime.UTC().Format(time.RFC3339),
+	}, opts...)
+	return err
+}
+
+type softwareUpdateWatcher struct {
+	deviceID  string
+	handler   SoftwareUpdateObservationHandler
+	onSuccess func()
+	done      chan struct{}
+
+	lock          sync.Mutex
+	initialized   bool
+	state         softwareupdate.UpdateState
+	initialResult int
+	inProgress    bool
+	closed        bool
+}
+
+// newSoftwareUpdateWatcher creates the watcher, onSuccess is called when the device reports the successful update.
+func newSoftwareUpdateWatcher(deviceID string, handler SoftwareUpdateObservationHandler, onSuccess func()) *softwareUpdateWatcher {
+	return &softwareUpdateWatcher{
+		deviceID:  deviceID,
+		handler:   handler,
+		onSuccess: onSuccess,
+		done:      make(chan struct{}),
+	}
+}
+
+// closeLocked marks the watcher as finished, the observation is stopped by the watch goroutine.
+func (w *softwareUpdateWatcher) closeLocked() {
+	w.closed = true
+	close(w.done)
+}
+
+func (w *softwareUpdateWatcher) isFinished(sw softwareupdate.SoftwareUpdate) bool {
+	if sw.UpdateState != softwareupdate.UpdateState_IDLE && sw.UpdateState != "" {
+		return false
+	}
+	result := sw.GetUpdateResult()
+	if result <= softwareupdate.UpdateResult_IDLE {
+		return false
+	}
+	return w.inProgress || result != w.initialResult
+}
+
+// close marks the watcher as finished, it returns false when the watcher has been already finished.
+func (w *softwareUpdateWatcher) close() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return false
+	}
+	w.closeLocked()
+	return true
+}
+
+// update changes the state of the watcher by the resource and returns the events for the handler. The handler is called
+// by the caller without the lock, because it can cancel the watch.
+func (w *softwareUpdateWatcher) update(sw softwareupdate.SoftwareUpdate) (events []SoftwareUpdateEvent, finished bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return nil, false
+	}
+	if !w.initialized {
+		w.initialized = true
+		w.initialResult = sw.GetUpdateResult()
+	} else if w.state == sw.UpdateState && !w.isFinished(sw) {
+		return nil, false
+	}
+	ev := SoftwareUpdateEvent{
+		DeviceID:      w.deviceID,
+		Event:         SoftwareUpdateEvent_STATE_CHANGED,
+		PreviousState: w.state,
+		State:         sw.UpdateState,
+		Result:        sw.GetUpdateResult(),
+		Resource:      sw,
+	}
+	if w.state != sw.UpdateState {
+		events = append(events, ev)
+	}
+	w.state = sw.UpdateState
+	if sw.UpdateState != softwareupdate.UpdateState_IDLE && sw.UpdateState != "" {
+		w.inProgress = true
+	}
+	if w.isFinished(sw) {
+		ev.Event = SoftwareUpdateEvent_FINISHED
+		events = append(events, ev)
+		w.closeLocked()
+		return events, true
+	}
+	return events, false
+}
+
+func (w *softwareUpdateWatcher) Handle(ctx context.Context, body coap.DecodeFunc) {
+	var sw softwareupdate.SoftwareUpdate
+	if err := body(&sw); err != nil {
+		if w.close() {
+			w.handler.Error(fmt.Errorf("cannot decode software update resource of device %v: %w", w.deviceID, err))
+		}
+		return
+	}
+	events, finished := w.update(sw)
+	if finished && sw.GetUpdateResult() == softwareupdate.UpdateResult_SUCCESS && w.onSuccess != nil {
+		w.onSuccess()
+	}
+	for _, ev := range events {
+		w.handler.Handle(ctx, ev)
+	}
+	if finished {
+		w.handler.OnClose()
+	}
+}
+
+func (w *softwareUpdateWatcher) timeout() {
+	w.lock.Lock()
+	if w.closed {
+		w.lock.Unlock()
+		return
+	}
+	state := w.state
+	w.closeLocked()
+	w.lock.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.handler.Handle(ctx, SoftwareUpdateEvent{
+		DeviceID:      w.deviceID,
+		Event:         SoftwareUpdateEvent_TIMEOUT,
+		PreviousState: state,
+		State:         state,
+		Result:        -1,
+	})
+	w.handler.OnClose()
+}
+
+func (w *softwareUpdateWatcher) cancel() {
+	w.close()
+}
+
+func (w *softwareUpdateWatcher) OnClose() {
+	if w.close() {
+		w.handler.OnClose()
+	}
+}
+
+func (w *softwareUpdateWatcher) Error(err error) {
+	if w.close() {
+		w.handler.Error(err)
+	}
+}
+
+type softwareUpdateSubscription struct {
+	watcher *softwareUpdateWatcher
+	wait    func()
+}
+
+func (s *softwareUpdateSubscription) Cancel() {
+	s.watcher.cancel()
+}
+
+func (s *softwareUpdateSubscription) Wait() {
+	s.wait()
+}
+
+// WatchSoftwareUpdate observes the software update resource of the device and reports the transitions of swupdatestate
+// (idle → nsa → svv → sva → upgrading) to the handler. The watch finishes with the swupdateresult reported by the device
+// or with the timeout event when the timeout is greater than 0 and the update doesn't finish in time.
+// Note: The device usually reboots during the upgrade, in that case the connection is closed and OnClose is called.
+func (c *Client) WatchSoftwareUpdate(ctx context.Context, deviceID string, timeout time.Duration, handler SoftwareUpdateObservationHandler, opts ...CommonCommandOption) (string, error) {
+	cfg := applyCommonOptions(opts...)
+	_, link, err := c.getSoftwareUpdateLink(ctx, deviceID, cfg)
+	if err != nil {
+		return "", err
+	}
+	observeOpts := []ObserveOption{WithDiscoveryConfiguration(cfg.discoveryConfiguration)}
+	watcher := newSoftwareUpdateWatcher(deviceID, handler, func() {
+		c.evictIntrospection(deviceID)
+	})
+	observationID, err := c.ObserveResource(ctx, deviceID, link.Href, watcher, observeOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	c.insertSubscription(observationID, &softwareUpdateSubscription{
+		watcher: watcher,
+		wait:    wg.Wait,
+	})
+	go func() {
+		defer wg.Done()
+		var timeoutC <-chan time.Time
+		if timeout > 0 {
+			t := time.NewTimer(timeout)
+			defer t.Stop()
+			timeoutC = t.C
+		}
+		select {
+		case <-watcher.done:
+		case <-timeoutC:
+			watcher.timeout()
+		}
+		_, _ = c.popSubscription(observationID)
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		if _, errS := c.StopObservingResource(stopCtx, observationID); errS != nil {
+			c.logger.Debug(fmt.Errorf("cannot stop software update observation of device %v: %w", deviceID, errS).Error())
+		}
+	}()
+	return observationID, nil
+}
+
+// StopWatchingSoftwareUpdate stops the software update watch, the handler doesn't receive OnClose.
+func (c *Client) StopWatchingSoftwareUpdate(watchID string) bool {
+	sub, err := c.popSubscription(watchID)
+	if err != nil {
+		return false
+	}
+	sub.Cancel()
+	sub.Wait()
+	return true
+}
@@ -0,0 +1,139 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/plgd-dev/device/v2/schema/softwareupdate"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSoftwareUpdateHandler struct {
+	events  []SoftwareUpdateEvent
+	closed  bool
+	err     error
+	onEvent func()
+}
+
+func (h *mockSoftwareUpdateHandler) Handle(_ context.Context, event SoftwareUpdateEvent) {
+	h.events = append(h.events, event)
+	if h.onEvent != nil {
+		h.onEvent()
+	}
+}
+
+func (h *mockSoftwareUpdateHandler) OnClose() { h.closed = true }
+
+func (h *mockSoftwareUpdateHandler) Error(err error) { h.err = err }
+
+func notifySoftwareUpdate(w *softwareUpdateWatcher, state softwareupdate.UpdateState, result *int) {
+	w.Handle(context.Background(), func(v interface{}) error {
+		sw, ok := v.(*softwareupdate.SoftwareUpdate)
+		if !ok {
+			return errors.New("invalid type")
+		}
+		*sw = softwareupdate.SoftwareUpdate{
+			UpdateState:  state,
+			UpdateResult: result,
+		}
+		return nil
+	})
+}
+
+func TestSoftwareUpdateWatcher(t *testing.T) {
+	h := &mockSoftwareUpdateHandler{}
+	succeeded := 0
+	w := newSoftwareUpdateWatcher("deviceID", h, func() { succeeded++ })
+	idle := softwareupdate.UpdateResult_IDLE
+	success := softwareupdate.UpdateResult_SUCCESS
+
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_IDLE, &idle)
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_IDLE, &idle)
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_NEW_SOFTWARE_AVAILABLE, &idle)
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_DOWNLOADING_VALIDATING, &idle)
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_DOWNLOAED_VALIDATED, &idle)
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_UPGRADING, &idle)
+	require.False(t, h.closed)
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_IDLE, &success)
+	// ignored after finish
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_NEW_SOFTWARE_AVAILABLE, &idle)
+
+	states := []softwareupdate.UpdateState{
+		softwareupdate.UpdateState_IDLE,
+		softwareupdate.UpdateState_NEW_SOFTWARE_AVAILABLE,
+		softwareupdate.UpdateState_DOWNLOADING_VALIDATING,
+		softwareupdate.UpdateState_DOWNLOAED_VALIDATED,
+		softwareupdate.UpdateState_UPGRADING,
+		softwareupdate.UpdateState_IDLE,
+	}
+	require.Len(t, h.events, len(states)+1)
+	for i, state := range states {
+		require.Equal(t, SoftwareUpdateEvent_STATE_CHANGED, h.events[i].Event)
+		require.Equal(t, state, h.events[i].State)
+	}
+	last := h.events[len(h.events)-1]
+	require.Equal(t, SoftwareUpdateEvent_FINISHED, last.Event)
+	require.Equal(t, softwareupdate.UpdateResult_SUCCESS, last.Result)
+	require.True(t, h.closed)
+	require.NoError(t, h.err)
+	require.Equal(t, 1, succeeded)
+}
+
+func TestSoftwareUpdateWatcherTimeout(t *testing.T) {
+	h := &mockSoftwareUpdateHandler{}
+	w := newSoftwareUpdateWatcher("deviceID", h, nil)
+	// result of the previous update is not reported as finished
+	failed := 2
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_IDLE, &failed)
+	w.timeout()
+	w.timeout()
+	require.Len(t, h.events, 2)
+	require.Equal(t, SoftwareUpdateEvent_TIMEOUT, h.events[1].Event)
+	require.True(t, h.closed)
+	select {
+	case <-w.done:
+	default:
+		require.Fail(t, "watcher is not done")
+	}
+}
+
+func TestSoftwareUpdateWatcherCancelFromHandler(t *testing.T) {
+	h := &mockSoftwareUpdateHandler{}
+	w := newSoftwareUpdateWatcher("deviceID", h, nil)
+	// the handler stops the watch, e.g. by StopWatchingSoftwareUpdate
+	h.onEvent = w.cancel
+	idle := softwareupdate.UpdateResult_IDLE
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_NEW_SOFTWARE_AVAILABLE, &idle)
+	notifySoftwareUpdate(w, softwareupdate.UpdateState_UPGRADING, &idle)
+	require.Len(t, h.events, 1)
+	require.False(t, h.closed)
+	select {
+	case <-w.done:
+	default:
+		require.Fail(t, "watcher is not done")
+	}
+
+	h = &mockSoftwareUpdateHandler{}
+	w = newSoftwareUpdateWatcher("deviceID", h, nil)
+	h.onEvent = w.cancel
+	w.timeout()
+	require.Len(t, h.events, 1)
+	require.True(t, h.closed)
+}
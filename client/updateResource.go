@@ -22,6 +22,7 @@ import (
 	"github.com/plgd-dev/device/v2/client/core"
 	codecOcf "github.com/plgd-dev/device/v2/pkg/codec/ocf"
 	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/schema/introspection"
 )
 
 // UpdateResource updates the device resource.
@@ -52,5 +53,14 @@ func (c *Client) UpdateResource(
 		cfg.opts = append(cfg.opts, coap.WithDeviceID(deviceID))
 	}
 
+	if cfg.validatePayload {
+		err = c.validatePayload(ctx, deviceID, cfg.discoveryConfiguration, func(doc *introspection.Document) error {
+			return doc.ValidateRequest(link.Href, "post", request)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return device.UpdateResourceWithCodec(ctx, link, cfg.codec, request, response, cfg.opts...)
 }
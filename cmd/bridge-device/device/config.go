@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/plgd-dev/device/v2/bridge/service"
 	"github.com/plgd-dev/device/v2/pkg/log"
@@ -32,10 +33,17 @@ type LogConfig struct {
 	Level log.Level `yaml:"level" json:"level" description:"log level"`
 }
 
+type BackoffConfig struct {
+	InitialInterval time.Duration `yaml:"initialInterval" json:"initialInterval" description:"delay after the first failed attempt to connect"`
+	MaxInterval     time.Duration `yaml:"maxInterval" json:"maxInterval" description:"maximal delay between the attempts to connect"`
+	MaxRetries      int           `yaml:"maxRetries" json:"maxRetries" description:"number of failed attempts after which the device stops connecting, negative value means unlimited"`
+}
+
 type CloudConfig struct {
-	Enabled bool      `yaml:"enabled" json:"enabled" description:"enable cloud connection"`
-	CloudID string    `yaml:"cloudID" json:"cloudID" description:"cloud id"`
-	TLS     TLSConfig `yaml:"tls" json:"tls"`
+	Enabled bool          `yaml:"enabled" json:"enabled" description:"enable cloud connection"`
+	CloudID string        `yaml:"cloudID" json:"cloudID" description:"cloud id"`
+	TLS     TLSConfig     `yaml:"tls" json:"tls"`
+	Backoff BackoffConfig `yaml:"backoff" json:"backoff"`
 }
 
 type CredentialConfig struct {
@@ -47,6 +55,18 @@ type ThingDescriptionConfig struct {
 	File    string `yaml:"file" json:"file" description:"file path to the thing description"`
 }
 
+type StoreConfig struct {
+	Enabled   bool   `yaml:"enabled" json:"enabled" description:"persist state of the bridged devices"`
+	Directory string `yaml:"directory" json:"directory" description:"directory where the state of the bridged devices is stored"`
+}
+
+func (c *StoreConfig) Validate() error {
+	if c.Enabled && c.Directory == "" {
+		return errors.New("directory is required")
+	}
+	return nil
+}
+
 func (c *CloudConfig) Validate() error {
 	if c.Enabled {
 		return c.TLS.Validate()
@@ -60,6 +80,7 @@ type Config struct {
 	Cloud                      CloudConfig            `yaml:"cloud" json:"cloud"`
 	Credential                 CredentialConfig       `yaml:"credential" json:"credential"`
 	ThingDescription           ThingDescriptionConfig `yaml:"thingDescription" json:"thingDescription"`
+	Store                      StoreConfig            `yaml:"store" json:"store"`
 	NumGeneratedBridgedDevices int                    `yaml:"numGeneratedBridgedDevices"`
 	NumResourcesPerDevice      int                    `yaml:"numResourcesPerDevice"`
 }
@@ -68,6 +89,9 @@ func (c *Config) Validate() error {
 	if err := c.Config.Validate(); err != nil {
 		return err
 	}
+	if err := c.Store.Validate(); err != nil {
+		return fmt.Errorf("store.%w", err)
+	}
 	if c.NumGeneratedBridgedDevices <= 0 {
 		return errors.New("numGeneratedBridgedDevices - must be > 0")
 	}
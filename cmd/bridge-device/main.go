@@ -74,7 +74,14 @@ func getCloudOpts(cfg bridgeDevice.Config) ([]device.Option, error) {
 	if err != nil {
 		return nil, err
 	}
-	opts := []device.Option{device.WithCAPool(caPool)}
+	opts := []device.Option{
+		device.WithCAPool(caPool),
+		device.WithCloudOptions(cloud.WithBackoff(cloud.BackoffConfig{
+			InitialInterval: cfg.Cloud.Backoff.InitialInterval,
+			MaxInterval:     cfg.Cloud.Backoff.MaxInterval,
+			MaxRetries:      cfg.Cloud.Backoff.MaxRetries,
+		})),
+	}
 	if cert != nil {
 		opts = append(opts, device.WithGetCertificates(func(string) []tls.Certificate {
 			return []tls.Certificate{*cert}
@@ -153,6 +160,40 @@ func getOpts(cfg bridgeDevice.Config) ([]device.Option, error) {
 	return opts, nil
 }
 
+func newService(cfg bridgeDevice.Config, logger log.Logger, newDeviceFromSpec service.NewDeviceFromSpecFunc) (*service.Service, error) {
+	opts := []service.Option{service.WithLogger(logger), service.WithNewDeviceFromSpec(newDeviceFromSpec)}
+	if cfg.Store.Enabled {
+		store, err := service.NewFileStore(cfg.Store.Directory)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, service.WithStore(store))
+	}
+	return service.New(cfg.Config, opts...)
+}
+
+func makeDeviceConfig(cfg bridgeDevice.Config, id, piid uuid.UUID, name string, resourceTypes []string) device.Config {
+	if len(resourceTypes) == 0 {
+		resourceTypes = []string{bridgeDevice.DeviceResourceType}
+	}
+	return device.Config{
+		Name:                  name,
+		ResourceTypes:         resourceTypes,
+		ID:                    id,
+		ProtocolIndependentID: piid,
+		MaxMessageSize:        cfg.API.CoAP.MaxMessageSize,
+		Cloud: device.CloudConfig{
+			Enabled: cfg.Cloud.Enabled,
+			Config: cloud.Config{
+				CloudID: cfg.Cloud.CloudID,
+			},
+		},
+		Credential: device.CredentialConfig{
+			Enabled: cfg.Credential.Enabled,
+		},
+	}
+}
+
 func main() {
 	configFile := flag.String("config", "config.yaml", "path to config file")
 	flag.Parse()
@@ -160,36 +201,51 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	s, err := service.New(cfg.Config, service.WithLogger(log.NewStdLogger(cfg.Log.Level)))
+	logger := log.NewStdLogger(cfg.Log.Level)
+
+	opts, err := getOpts(cfg)
 	if err != nil {
 		panic(err)
 	}
+	var s *service.Service
+	opts = append(opts, device.WithOnDeviceUpdated(func(d *device.Device) {
+		s.OnDeviceUpdated(d)
+	}))
+	newDevice := func(devCfg device.Config) (service.Device, error) {
+		return device.New(devCfg, append(opts, device.WithLogger(device.NewLogger(devCfg.ID, cfg.Log.Level)))...)
+	}
 
-	opts, err := getOpts(cfg)
+	s, err = newService(cfg, logger, func(spec service.DeviceSpec, piid uuid.UUID) (service.Device, error) {
+		d, errN := newDevice(makeDeviceConfig(cfg, spec.ID, piid, spec.Name, spec.ResourceTypes))
+		if errN != nil {
+			return nil, errN
+		}
+		addResources(d, cfg.NumResourcesPerDevice)
+		return d, nil
+	})
 	if err != nil {
 		panic(err)
 	}
 
-	for i := 0; i < cfg.NumGeneratedBridgedDevices; i++ {
-		newDevice := func(id uuid.UUID, piid uuid.UUID) (service.Device, error) {
-			return device.New(device.Config{
-				Name:                  fmt.Sprintf("bridged-device-%d", i),
-				ResourceTypes:         []string{bridgeDevice.DeviceResourceType},
-				ID:                    id,
-				ProtocolIndependentID: piid,
-				MaxMessageSize:        cfg.API.CoAP.MaxMessageSize,
-				Cloud: device.CloudConfig{
-					Enabled: cfg.Cloud.Enabled,
-					Config: cloud.Config{
-						CloudID: cfg.Cloud.CloudID,
-					},
-				},
-				Credential: device.CredentialConfig{
-					Enabled: cfg.Credential.Enabled,
-				},
-			}, append(opts, device.WithLogger(device.NewLogger(id, cfg.Log.Level)))...)
-		}
-		d, errC := s.CreateDevice(uuid.New(), newDevice)
+	restoredDevices, err := s.RestoreDevices(func(devCfg device.Config) (service.Device, error) {
+		// settings of the bridge take precedence over the stored ones
+		devCfg.MaxMessageSize = cfg.API.CoAP.MaxMessageSize
+		devCfg.Cloud.Enabled = cfg.Cloud.Enabled
+		devCfg.Credential.Enabled = cfg.Credential.Enabled
+		return newDevice(devCfg)
+	})
+	if err != nil {
+		logger.Errorf("cannot restore devices: %w", err)
+	}
+	for _, d := range restoredDevices {
+		addResources(d, cfg.NumResourcesPerDevice)
+		d.Init()
+	}
+
+	for i := len(restoredDevices); i < cfg.NumGeneratedBridgedDevices; i++ {
+		d, errC := s.CreateDevice(uuid.New(), func(id uuid.UUID, piid uuid.UUID) (service.Device, error) {
+			return newDevice(makeDeviceConfig(cfg, id, piid, fmt.Sprintf("bridged-device-%d", i), nil))
+		})
 		if errC == nil {
 			addResources(d, cfg.NumResourcesPerDevice)
 			d.Init()
@@ -0,0 +1,41 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+// Package backoff computes the delays between the retries of the failed operations.
+package backoff
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Delay returns the delay before the attempt, the attempts are numbered from 1. The delay starts at initialInterval
+// and doubles with each attempt up to maxInterval. Half of the delay is fixed and the other half is random, so the
+// retries of many clients are spread in time.
+func Delay(initialInterval, maxInterval time.Duration, attempt int) time.Duration {
+	d := initialInterval
+	for i := 1; i < attempt && d < maxInterval; i++ {
+		d *= 2
+	}
+	if d > maxInterval {
+		d = maxInterval
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half+1) //nolint:gosec
+}
@@ -0,0 +1,43 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package backoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/backoff"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelay(t *testing.T) {
+	for attempt, maxDelay := range map[int]time.Duration{
+		0:  time.Second,
+		1:  time.Second,
+		2:  time.Second * 2,
+		3:  time.Second * 4,
+		4:  time.Second * 5,
+		10: time.Second * 5,
+	} {
+		for range 10 {
+			d := backoff.Delay(time.Second, time.Second*5, attempt)
+			require.GreaterOrEqual(t, d, maxDelay/2)
+			require.LessOrEqual(t, d, maxDelay)
+		}
+	}
+	require.Equal(t, time.Nanosecond, backoff.Delay(time.Nanosecond, time.Nanosecond, 1))
+}
@@ -18,6 +18,7 @@ package coap
 
 import (
 	"encoding/base64"
+	"fmt"
 	"strings"
 )
 
@@ -56,3 +57,27 @@ func EncodeETagsForIncrementalChanges(etags [][]byte) []string {
 	}
 	return etagsStr
 }
+
+// DecodeETagsForIncrementalChanges decodes the ETags from the "incChanges" URI queries, the other queries are ignored.
+func DecodeETagsForIncrementalChanges(queries []string) ([][]byte, error) {
+	var etags [][]byte
+	for _, q := range queries {
+		if !strings.HasPrefix(q, prefixQueryIncChanges) {
+			continue
+		}
+		for _, etagStr := range strings.Split(strings.TrimPrefix(q, prefixQueryIncChanges), ",") {
+			if etagStr == "" {
+				continue
+			}
+			etag, err := base64.RawURLEncoding.DecodeString(etagStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid etag('%v'): %w", etagStr, err)
+			}
+			if len(etag) > maxETagLen {
+				return nil, fmt.Errorf("invalid etag('%v'): too long", etagStr)
+			}
+			etags = append(etags, etag)
+		}
+	}
+	return etags, nil
+}
@@ -107,3 +107,24 @@ func TestObservedResourceEncodeETagsForIncrementChanged(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeETagsForIncrementalChanges(t *testing.T) {
+	etags := [][]byte{
+		[]byte("01234567"),
+		[]byte("1"),
+		[]byte("2"),
+	}
+	queries := append([]string{"if=oic.if.b"}, EncodeETagsForIncrementalChanges(etags)...)
+	got, err := DecodeETagsForIncrementalChanges(queries)
+	require.NoError(t, err)
+	require.Equal(t, etags, got)
+
+	got, err = DecodeETagsForIncrementalChanges([]string{"if=oic.if.b"})
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	_, err = DecodeETagsForIncrementalChanges([]string{prefixQueryIncChanges + "!!"})
+	require.Error(t, err)
+	_, err = DecodeETagsForIncrementalChanges([]string{prefixQueryIncChanges + "MDEyMzQ1Njc4"})
+	require.Error(t, err)
+}
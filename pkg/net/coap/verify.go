@@ -26,7 +26,10 @@ import (
 	"github.com/google/uuid"
 )
 
-var ExtendedKeyUsage_IDENTITY_CERTIFICATE = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44924, 1, 6}
+var (
+	ExtendedKeyUsage_IDENTITY_CERTIFICATE = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44924, 1, 6}
+	ExtendedKeyUsage_ROLE_CERTIFICATE     = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 44924, 1, 7}
+)
 
 func verifyOcfEKU(cert *x509.Certificate) error {
 	hasOcfID := false
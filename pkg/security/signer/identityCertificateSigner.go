@@ -52,11 +52,12 @@ func NewOCFIdentityCertificate(caCert []*x509.Certificate, caKey crypto.PrivateK
 	}, nil
 }
 
-func (s *OCFIdentityCertificate) Sign(_ context.Context, csr []byte) ([]byte, error) {
+// validityPeriod limits the validity period by the validity of the CA chain.
+func validityPeriod(caCert []*x509.Certificate, validNotBefore, validNotAfter time.Time) (time.Time, time.Time, error) {
 	now := time.Now()
-	notBefore := s.validNotBefore
-	notAfter := s.validNotAfter
-	for _, c := range s.caCert {
+	notBefore := validNotBefore
+	notAfter := validNotAfter
+	for _, c := range caCert {
 		if notBefore.Before(c.NotBefore) {
 			notBefore = c.NotBefore
 		}
@@ -65,13 +66,21 @@ func (s *OCFIdentityCertificate) Sign(_ context.Context, csr []byte) ([]byte, er
 		}
 	}
 	if notBefore.After(notAfter) {
-		return nil, fmt.Errorf("invalid time range: not before %v limit is greater than not after limit %v", notBefore.Format(time.RFC3339), notAfter.Format(time.RFC3339))
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time range: not before %v limit is greater than not after limit %v", notBefore.Format(time.RFC3339), notAfter.Format(time.RFC3339))
 	}
 	if now.Before(notBefore) {
-		return nil, fmt.Errorf("not valid yet: current time %v is out of time range: %v <-> %v", now, notBefore.Format(time.RFC3339), notAfter.Format(time.RFC3339))
+		return time.Time{}, time.Time{}, fmt.Errorf("not valid yet: current time %v is out of time range: %v <-> %v", now, notBefore.Format(time.RFC3339), notAfter.Format(time.RFC3339))
 	}
 	if now.After(notAfter) {
-		return nil, fmt.Errorf("expired: current time %v is out of time range: %v <-> %v", now, notBefore.Format(time.RFC3339), notAfter.Format(time.RFC3339))
+		return time.Time{}, time.Time{}, fmt.Errorf("expired: current time %v is out of time range: %v <-> %v", now, notBefore.Format(time.RFC3339), notAfter.Format(time.RFC3339))
+	}
+	return notBefore, notAfter, nil
+}
+
+func (s *OCFIdentityCertificate) Sign(_ context.Context, csr []byte) ([]byte, error) {
+	notBefore, notAfter, err := validityPeriod(s.caCert, s.validNotBefore, s.validNotAfter)
+	if err != nil {
+		return nil, err
 	}
 
 	certificateRequest, err := pkgX509.ParseAndCheckCertificateRequest(csr)
@@ -0,0 +1,101 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	pkgX509 "github.com/plgd-dev/device/v2/pkg/security/x509"
+)
+
+// OCFRoleCertificate signs role certificates, the roles are stored in the subject alternative name
+// of the certificate as defined by the OCF security specification.
+type OCFRoleCertificate struct {
+	caCert                []*x509.Certificate
+	caKey                 crypto.PrivateKey
+	validNotBefore        time.Time
+	validNotAfter         time.Time
+	crlDistributionPoints []string
+	rolesExtension        pkix.Extension
+}
+
+func NewOCFRoleCertificate(caCert []*x509.Certificate, caKey crypto.PrivateKey, validNotBefore, validNotAfter time.Time, crlDistributionPoints []string, roles []pkgX509.Role) (*OCFRoleCertificate, error) {
+	if err := pkgX509.ValidateCRLDistributionPoints(crlDistributionPoints); err != nil {
+		return nil, err
+	}
+	rolesExtension, err := pkgX509.MarshalRolesExtension(roles)
+	if err != nil {
+		return nil, err
+	}
+	return &OCFRoleCertificate{
+		caCert:                caCert,
+		caKey:                 caKey,
+		validNotBefore:        validNotBefore,
+		validNotAfter:         validNotAfter,
+		crlDistributionPoints: crlDistributionPoints,
+		rolesExtension:        rolesExtension,
+	}, nil
+}
+
+func (s *OCFRoleCertificate) Sign(_ context.Context, csr []byte) ([]byte, error) {
+	notBefore, notAfter, err := validityPeriod(s.caCert, s.validNotBefore, s.validNotAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	certificateRequest, err := pkgX509.ParseAndCheckCertificateRequest(csr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.caCert) == 0 {
+		return nil, errors.New("cannot sign with empty signer CA certificates")
+	}
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, err
+	}
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		Subject:               certificateRequest.Subject,
+		PublicKeyAlgorithm:    certificateRequest.PublicKeyAlgorithm,
+		PublicKey:             certificateRequest.PublicKey,
+		SignatureAlgorithm:    s.caCert[0].SignatureAlgorithm,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyAgreement,
+		UnknownExtKeyUsage:    []asn1.ObjectIdentifier{coap.ExtendedKeyUsage_ROLE_CERTIFICATE},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		ExtraExtensions:       []pkix.Extension{s.rolesExtension},
+		CRLDistributionPoints: s.crlDistributionPoints,
+	}
+	signedCsr, err := x509.CreateCertificate(rand.Reader, &template, s.caCert[0], certificateRequest.PublicKey, s.caKey)
+	if err != nil {
+		return nil, err
+	}
+	return pkgX509.CreatePemChain(s.caCert, signedCsr)
+}
@@ -0,0 +1,70 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package signer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/plgd-dev/device/v2/pkg/net/coap"
+	"github.com/plgd-dev/device/v2/pkg/security/generateCertificate"
+	"github.com/plgd-dev/device/v2/pkg/security/signer"
+	pkgX509 "github.com/plgd-dev/device/v2/pkg/security/x509"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCFRoleCertificateSign(t *testing.T) {
+	cfg := generateCertificate.Configuration{
+		ValidFor: time.Hour * 24,
+	}
+	caKey, err := cfg.GenerateKey()
+	require.NoError(t, err)
+	caPem, err := generateCertificate.GenerateRootCA(cfg, caKey)
+	require.NoError(t, err)
+	caCert, err := pkgX509.ParsePemCertificates(caPem)
+	require.NoError(t, err)
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	csr, err := generateCertificate.GenerateIdentityCSR(generateCertificate.Configuration{}, uuid.NewString(), priv)
+	require.NoError(t, err)
+
+	roles := []pkgX509.Role{
+		{Role: "admin", Authority: "plgd"},
+		{Role: "operator"},
+	}
+	_, err = signer.NewOCFRoleCertificate(caCert, caKey, time.Now().Add(-time.Second), time.Now().Add(time.Hour), nil, nil)
+	require.Error(t, err)
+	_, err = signer.NewOCFRoleCertificate(caCert, caKey, time.Now().Add(-time.Second), time.Now().Add(time.Hour), nil, []pkgX509.Role{{Authority: "plgd"}})
+	require.Error(t, err)
+
+	s, err := signer.NewOCFRoleCertificate(caCert, caKey, time.Now().Add(-time.Second), time.Now().Add(time.Hour), nil, roles)
+	require.NoError(t, err)
+	certPem, err := s.Sign(context.Background(), csr)
+	require.NoError(t, err)
+	certs, err := pkgX509.ParsePemCertificates(certPem)
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	require.Contains(t, certs[0].UnknownExtKeyUsage, coap.ExtendedKeyUsage_ROLE_CERTIFICATE)
+	gotRoles, err := pkgX509.ParseRoles(certs[0])
+	require.NoError(t, err)
+	require.Equal(t, roles, gotRoles)
+}
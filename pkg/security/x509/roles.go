@@ -0,0 +1,121 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package x509
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+var oidExtensionSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+// directoryName tag of the GeneralName defined by RFC 5280
+const nameTypeDirectoryName = 4
+
+// Role is an OCF role asserted by a role certificate.
+type Role struct {
+	// Role is stored in the common name of the directoryName.
+	Role string
+	// Authority is stored in the organizational unit of the directoryName, it is optional.
+	Authority string
+}
+
+func (r Role) toName() pkix.Name {
+	n := pkix.Name{
+		CommonName: r.Role,
+	}
+	if r.Authority != "" {
+		n.OrganizationalUnit = []string{r.Authority}
+	}
+	return n
+}
+
+// MarshalRolesExtension encodes the roles to the subject alternative name extension as directoryName entries.
+func MarshalRolesExtension(roles []Role) (pkix.Extension, error) {
+	if len(roles) == 0 {
+		return pkix.Extension{}, errors.New("empty roles")
+	}
+	names := make([]asn1.RawValue, 0, len(roles))
+	for _, r := range roles {
+		if r.Role == "" {
+			return pkix.Extension{}, errors.New("invalid role: empty name")
+		}
+		rdn, err := asn1.Marshal(r.toName().ToRDNSequence())
+		if err != nil {
+			return pkix.Extension{}, fmt.Errorf("cannot marshal role %v: %w", r.Role, err)
+		}
+		names = append(names, asn1.RawValue{
+			Class:      asn1.ClassContextSpecific,
+			Tag:        nameTypeDirectoryName,
+			IsCompound: true,
+			Bytes:      rdn,
+		})
+	}
+	value, err := asn1.Marshal(names)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{
+		Id:    oidExtensionSubjectAltName,
+		Value: value,
+	}, nil
+}
+
+// ParseRoles decodes the roles from the directoryName entries of the subject alternative name extension.
+func ParseRoles(cert *x509.Certificate) ([]Role, error) {
+	var roles []Role
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidExtensionSubjectAltName) {
+			continue
+		}
+		var seq asn1.RawValue
+		if _, err := asn1.Unmarshal(ext.Value, &seq); err != nil {
+			return nil, fmt.Errorf("cannot parse subject alternative name: %w", err)
+		}
+		rest := seq.Bytes
+		for len(rest) > 0 {
+			var v asn1.RawValue
+			var err error
+			rest, err = asn1.Unmarshal(rest, &v)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse subject alternative name: %w", err)
+			}
+			if v.Class != asn1.ClassContextSpecific || v.Tag != nameTypeDirectoryName {
+				continue
+			}
+			var rdn pkix.RDNSequence
+			if _, err = asn1.Unmarshal(v.Bytes, &rdn); err != nil {
+				return nil, fmt.Errorf("cannot parse directory name: %w", err)
+			}
+			var n pkix.Name
+			n.FillFromRDNSequence(&rdn)
+			r := Role{
+				Role: n.CommonName,
+			}
+			if len(n.OrganizationalUnit) > 0 {
+				r.Authority = n.OrganizationalUnit[0]
+			}
+			roles = append(roles, r)
+		}
+	}
+	return roles, nil
+}
@@ -0,0 +1,51 @@
+/****************************************************************************
+ *
+ * Copyright (c) 2026 plgd.dev s.r.o.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"),
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the specific
+ * language governing permissions and limitations under the License.
+ *
+ ****************************************************************************/
+
+package x509_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	pkgX509 "github.com/plgd-dev/device/v2/pkg/security/x509"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolesExtension(t *testing.T) {
+	_, err := pkgX509.MarshalRolesExtension(nil)
+	require.Error(t, err)
+	_, err = pkgX509.MarshalRolesExtension([]pkgX509.Role{{Authority: "plgd"}})
+	require.Error(t, err)
+
+	roles := []pkgX509.Role{
+		{Role: "admin", Authority: "plgd"},
+		{Role: "operator"},
+	}
+	ext, err := pkgX509.MarshalRolesExtension(roles)
+	require.NoError(t, err)
+	got, err := pkgX509.ParseRoles(&x509.Certificate{
+		Extensions: []pkix.Extension{ext},
+	})
+	require.NoError(t, err)
+	require.Equal(t, roles, got)
+
+	got, err = pkgX509.ParseRoles(&x509.Certificate{})
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
@@ -57,3 +57,64 @@ func TestPermissionString(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessControlEqual(t *testing.T) {
+	a := acl.AccessControl{
+		ID:         1,
+		Permission: acl.Permission_READ,
+		Subject:    acl.TLSConnection,
+		Resources: []acl.Resource{
+			{Href: "/a", Interfaces: []string{"oic.if.r", "oic.if.baseline"}},
+			{Href: "/b", Interfaces: []string{"*"}},
+		},
+		Validity: []acl.TimePattern{{Period: "20260101T000000/20270101T000000"}},
+	}
+	b := acl.AccessControl{
+		ID:         2,
+		Permission: acl.Permission_READ,
+		Subject: acl.Subject{
+			Subject_Connection: &acl.Subject_Connection{Type: acl.ConnectionType_AUTH_CRYPT},
+		},
+		Resources: []acl.Resource{
+			{Href: "/b", Interfaces: []string{"*"}},
+			{Href: "/a", Interfaces: []string{"oic.if.baseline", "oic.if.r"}},
+		},
+		Validity: []acl.TimePattern{{Period: "20260101T000000/20270101T000000"}},
+	}
+	require.True(t, a.Equal(b))
+
+	b.Subject = acl.Subject{Subject_Role: &acl.Subject_Role{Role: "admin"}}
+	require.False(t, a.Equal(b))
+}
+
+func TestDiff(t *testing.T) {
+	owner := acl.AccessControl{
+		Permission: acl.AllPermissions,
+		Subject:    acl.Subject{Subject_Device: &acl.Subject_Device{DeviceID: "owner"}},
+		Resources:  acl.AllResources,
+	}
+	role := acl.AccessControl{
+		Permission: acl.Permission_READ,
+		Subject:    acl.Subject{Subject_Role: &acl.Subject_Role{Authority: "site", Role: "operator"}},
+		Resources:  acl.AllResources,
+	}
+	anon := acl.AccessControl{
+		Permission: acl.Permission_READ,
+		Subject:    acl.Subject{Subject_Connection: &acl.Subject_Connection{Type: acl.ConnectionType_ANON_CLEAR}},
+		Resources:  []acl.Resource{{Href: "/oic/d", Interfaces: []string{"*"}}},
+	}
+	withID := func(ac acl.AccessControl, id int) acl.AccessControl {
+		ac.ID = id
+		return ac
+	}
+
+	current := []acl.AccessControl{withID(owner, 1), withID(owner, 2), withID(anon, 3)}
+	desired := []acl.AccessControl{owner, role}
+	remove, add := acl.Diff(current, desired)
+	require.Equal(t, []int{2, 3}, remove)
+	require.Equal(t, []acl.AccessControl{role}, add)
+
+	remove, add = acl.Diff([]acl.AccessControl{withID(owner, 1), withID(role, 2)}, desired)
+	require.Empty(t, remove)
+	require.Empty(t, add)
+}
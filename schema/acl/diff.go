@@ -0,0 +1,103 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package acl
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+)
+
+func sortedCopy(v []string) []string {
+	v = slices.Clone(v)
+	sort.Strings(v)
+	return v
+}
+
+func (r Resource) normalize() Resource {
+	r.Interfaces = sortedCopy(r.Interfaces)
+	r.ResourceTypes = sortedCopy(r.ResourceTypes)
+	return r
+}
+
+// key returns the canonical representation of the access control, the ID is ignored.
+func (ac AccessControl) key() string {
+	ac.ID = 0
+	resources := make([]Resource, 0, len(ac.Resources))
+	for _, r := range ac.Resources {
+		resources = append(resources, r.normalize())
+	}
+	resourceKeys := make([]string, 0, len(resources))
+	for _, r := range resources {
+		b, _ := json.Marshal(r)
+		resourceKeys = append(resourceKeys, string(b))
+	}
+	sort.Strings(resourceKeys)
+	validity := make([]string, 0, len(ac.Validity))
+	for _, v := range ac.Validity {
+		validity = append(validity, v.Period+"\x00"+v.Recurrence)
+	}
+	sort.Strings(validity)
+	subject, _ := json.Marshal(ac.Subject)
+	b, _ := json.Marshal(struct {
+		Permission Permission
+		Subject    string
+		Resources  []string
+		Tag        string
+		Validity   string
+	}{
+		Permission: ac.Permission,
+		Subject:    string(subject),
+		Resources:  resourceKeys,
+		Tag:        ac.Tag,
+		Validity:   strings.Join(validity, "\x01"),
+	})
+	return string(b)
+}
+
+// Equal compares access controls regardless of their IDs and the order of resources, interfaces, resource types and time patterns.
+func (ac AccessControl) Equal(other AccessControl) bool {
+	return ac.key() == other.key()
+}
+
+// Diff computes the minimal changes which transform the current access control list into the desired one.
+// The access controls of current which are not desired and the duplicates are returned by IDs in remove,
+// the missing desired access controls are returned in add.
+func Diff(current, desired []AccessControl) (remove []int, add []AccessControl) {
+	wanted := make(map[string]int, len(desired))
+	for _, ac := range desired {
+		wanted[ac.key()]++
+	}
+	for _, ac := range current {
+		k := ac.key()
+		if wanted[k] > 0 {
+			wanted[k]--
+			continue
+		}
+		remove = append(remove, ac.ID)
+	}
+	for _, ac := range desired {
+		k := ac.key()
+		if wanted[k] > 0 {
+			wanted[k]--
+			ac.ID = 0
+			add = append(add, ac)
+		}
+	}
+	return remove, add
+}
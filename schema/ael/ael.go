@@ -18,7 +18,52 @@
 // https://github.com/openconnectivityfoundation/security/blob/master/swagger2.0/oic.sec.ael.swagger.json
 package ael
 
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
 const (
 	ResourceType = "oic.r.ael"
 	ResourceURI  = "/oic/sec/ael"
 )
+
+// AccessEventLog contains the supported fields of the Auditable Event List resource.
+type AccessEventLog struct {
+	Interfaces     []string `json:"if,omitempty"`
+	ResourceTypes  []string `json:"rt,omitempty"`
+	Name           string   `json:"n,omitempty"`
+	CategoryFilter uint8    `json:"categoryfilter"` // only events with the category in the filter are logged
+	PriorityFilter uint8    `json:"priorityfilter"` // only events with the priority lower or equal to the filter are logged
+	MaxSpace       uint64   `json:"maxspace,omitempty"`
+	UsedSpace      uint64   `json:"usedspace,omitempty"`
+	Unit           string   `json:"unit,omitempty"` // unit of maxspace and usedspace, eg. "Byte" or "Kbyte"
+	Events         []Event  `json:"events"`
+}
+
+// Event is an auditable event logged by the device.
+type Event struct {
+	ID            string   `json:"aeid,omitempty"`
+	Category      uint8    `json:"category"`
+	Priority      uint8    `json:"priority"`
+	Timestamp     string   `json:"timestamp"` // time in RFC3339Nano format
+	Message       string   `json:"message,omitempty"`
+	AuxiliaryInfo []string `json:"auxinfo,omitempty"`
+}
+
+func (e Event) GetTimestamp() (time.Time, error) {
+	if e.Timestamp == "" {
+		return time.Time{}, errors.New("timestamp is empty")
+	}
+	return time.Parse(time.RFC3339Nano, e.Timestamp)
+}
+
+// Key identifies the event in the log, the ID is used when it is set by the device.
+func (e Event) Key() string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return strings.Join([]string{e.Timestamp, strconv.Itoa(int(e.Category)), strconv.Itoa(int(e.Priority)), e.Message}, "|")
+}
@@ -0,0 +1,50 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package ael_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/plgd-dev/device/v2/schema/ael"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventGetTimestamp(t *testing.T) {
+	now := time.Now().UTC()
+	e := ael.Event{Timestamp: now.Format(time.RFC3339Nano)}
+	ts, err := e.GetTimestamp()
+	require.NoError(t, err)
+	require.True(t, now.Equal(ts))
+
+	_, err = ael.Event{}.GetTimestamp()
+	require.Error(t, err)
+	_, err = ael.Event{Timestamp: "invalid"}.GetTimestamp()
+	require.Error(t, err)
+}
+
+func TestEventKey(t *testing.T) {
+	e := ael.Event{
+		Category:  1,
+		Priority:  2,
+		Timestamp: "2026-01-01T00:00:00Z",
+		Message:   "msg",
+	}
+	require.Equal(t, "2026-01-01T00:00:00Z|1|2|msg", e.Key())
+	e.ID = "id"
+	require.Equal(t, "id", e.Key())
+}
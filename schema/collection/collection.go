@@ -18,6 +18,32 @@
 // https://github.com/openconnectivityfoundation/core/blob/master/swagger2.0/oic.wk.col.swagger.json
 package collection
 
+import "github.com/plgd-dev/device/v2/schema"
+
 const (
 	ResourceType = "oic.wk.col"
 )
+
+// Collection is the representation of the collection with the baseline interface.
+type Collection struct {
+	ResourceTypes []string             `json:"rt,omitempty"`
+	Interfaces    []string             `json:"if,omitempty"`
+	Links         schema.ResourceLinks `json:"links"`
+}
+
+// CreateRequest is the payload of the request with the oic.if.create interface, which creates the resource in the collection.
+type CreateRequest struct {
+	ResourceTypes  []string       `json:"rt"`
+	Interfaces     []string       `json:"if"`
+	Policy         *schema.Policy `json:"p,omitempty"`
+	Representation interface{}    `json:"rep,omitempty"`
+}
+
+// CreateResponse is the payload of the response to the oic.if.create request.
+type CreateResponse struct {
+	Href           string         `json:"href"`
+	ResourceTypes  []string       `json:"rt"`
+	Interfaces     []string       `json:"if"`
+	Policy         *schema.Policy `json:"p,omitempty"`
+	Representation interface{}    `json:"rep,omitempty"`
+}
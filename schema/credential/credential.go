@@ -19,6 +19,9 @@
 package credential
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -189,6 +192,40 @@ func (c CredentialPublicData) Data() []byte {
 	return toByte(c.DataInternal)
 }
 
+// Certificates decodes the PEM or DER encoded certificates of the public data.
+func (c CredentialPublicData) Certificates() ([]*x509.Certificate, error) {
+	data := c.Data()
+	if len(data) == 0 {
+		return nil, errors.New("empty data")
+	}
+	switch c.Encoding {
+	case CredentialPublicDataEncoding_DER:
+		return x509.ParseCertificates(data)
+	case CredentialPublicDataEncoding_PEM:
+		var certs []*x509.Certificate
+		for {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, cert)
+		}
+		if len(certs) == 0 {
+			return nil, errors.New("no certificate found")
+		}
+		return certs, nil
+	}
+	return nil, fmt.Errorf("unsupported encoding %v", c.Encoding)
+}
+
 type CredentialPublicDataEncoding string
 
 const (
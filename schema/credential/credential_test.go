@@ -17,7 +17,15 @@
 package credential_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/plgd-dev/device/v2/schema/credential"
 	"github.com/stretchr/testify/require"
@@ -124,3 +132,47 @@ func TestCredentialTypeString(t *testing.T) {
 		})
 	}
 }
+
+func TestCredentialPublicDataCertificates(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	certs, err := credential.CredentialPublicData{
+		DataInternal: string(append(pemData, pemData...)),
+		Encoding:     credential.CredentialPublicDataEncoding_PEM,
+	}.Certificates()
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	require.Equal(t, "test", certs[0].Subject.CommonName)
+
+	certs, err = credential.CredentialPublicData{
+		DataInternal: der,
+		Encoding:     credential.CredentialPublicDataEncoding_DER,
+	}.Certificates()
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+
+	_, err = credential.CredentialPublicData{
+		DataInternal: "invalid",
+		Encoding:     credential.CredentialPublicDataEncoding_PEM,
+	}.Certificates()
+	require.Error(t, err)
+
+	_, err = credential.CredentialPublicData{
+		DataInternal: "data",
+		Encoding:     credential.CredentialPublicDataEncoding_RAW,
+	}.Certificates()
+	require.Error(t, err)
+
+	_, err = credential.CredentialPublicData{}.Certificates()
+	require.Error(t, err)
+}
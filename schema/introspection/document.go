@@ -0,0 +1,580 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package introspection
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	"github.com/plgd-dev/device/v2/schema/interfaces"
+)
+
+// ErrInvalidPayload is returned when the payload doesn't match the schema of the resource.
+var ErrInvalidPayload = errors.New("invalid payload")
+
+// Document is a subset of the swagger 2.0 introspection device data which is needed for payload validation.
+type Document struct {
+	Swagger     string               `json:"swagger"`
+	Info        Info                 `json:"info"`
+	Paths       map[string]PathItem  `json:"paths"`
+	Parameters  map[string]Parameter `json:"parameters,omitempty"`
+	Definitions map[string]*Schema   `json:"definitions,omitempty"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+}
+
+type Operation struct {
+	Parameters []Parameter `json:"parameters,omitempty"`
+}
+
+type Parameter struct {
+	Ref      string  `json:"$ref,omitempty"`
+	Name     string  `json:"name,omitempty"`
+	In       string  `json:"in,omitempty"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema,omitempty"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"` // string or array of strings
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	ReadOnly             bool               `json:"readOnly,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"` // bool or schema
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+func (s *Schema) types() []string {
+	switch v := s.Type.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		types := make([]string, 0, len(v))
+		for _, t := range v {
+			if str, ok := t.(string); ok {
+				types = append(types, str)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func (p PathItem) operation(method string) *Operation {
+	switch strings.ToLower(method) {
+	case "get":
+		return p.Get
+	case "post":
+		return p.Post
+	case "put":
+		return p.Put
+	case "delete":
+		return p.Delete
+	}
+	return nil
+}
+
+// findPath finds the path item of the href. The query part of the swagger path is ignored except the interface:
+// when iface is set, the path must be described for the interface, otherwise the paths of the create interface are
+// skipped, because they describe the created resource.
+func (d *Document) findPath(href, iface string) (PathItem, bool) {
+	if p, ok := d.Paths[href]; ok && iface == "" {
+		return p, true
+	}
+	keys := make([]string, 0, len(d.Paths))
+	for k := range d.Paths {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		path, query, _ := strings.Cut(k, "?")
+		if path != href {
+			continue
+		}
+		values, _ := url.ParseQuery(query)
+		ifs := values["if"]
+		if (iface == "" && !slices.Contains(ifs, interfaces.OC_IF_CREATE)) || (iface != "" && slices.Contains(ifs, iface)) {
+			return d.Paths[k], true
+		}
+	}
+	return PathItem{}, false
+}
+
+func (d *Document) resolveParameter(p Parameter) (Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, ok := strings.CutPrefix(p.Ref, "#/parameters/")
+	if !ok {
+		return Parameter{}, fmt.Errorf("unsupported reference %v", p.Ref)
+	}
+	v, ok := d.Parameters[name]
+	if !ok {
+		return Parameter{}, fmt.Errorf("cannot resolve reference %v", p.Ref)
+	}
+	return v, nil
+}
+
+func (d *Document) resolveSchema(s *Schema) (*Schema, error) {
+	for i := 0; s != nil && s.Ref != ""; i++ {
+		if i > 32 {
+			return nil, fmt.Errorf("too many nested references %v", s.Ref)
+		}
+		name, ok := strings.CutPrefix(s.Ref, "#/definitions/")
+		if !ok {
+			return nil, fmt.Errorf("unsupported reference %v", s.Ref)
+		}
+		v, ok := d.Definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("cannot resolve reference %v", s.Ref)
+		}
+		s = v
+	}
+	return s, nil
+}
+
+// GetRequestSchema returns the schema of the body of the request. It returns false when the document
+// doesn't describe the request.
+func (d *Document) GetRequestSchema(href, method string) (*Schema, bool, error) {
+	return d.getRequestSchema(href, "", method)
+}
+
+func (d *Document) getRequestSchema(href, iface, method string) (*Schema, bool, error) {
+	if d == nil {
+		return nil, false, nil
+	}
+	path, ok := d.findPath(href, iface)
+	if !ok {
+		return nil, false, nil
+	}
+	op := path.operation(method)
+	if op == nil {
+		return nil, false, nil
+	}
+	for _, p := range op.Parameters {
+		p, err := d.resolveParameter(p)
+		if err != nil {
+			return nil, false, err
+		}
+		if p.In != "body" || p.Schema == nil {
+			continue
+		}
+		s, err := d.resolveSchema(p.Schema)
+		if err != nil {
+			return nil, false, err
+		}
+		return s, true, nil
+	}
+	return nil, false, nil
+}
+
+// ValidateRequest validates the body of the request to the href. Requests which are not described
+// by the document are not validated.
+func (d *Document) ValidateRequest(href, method string, payload interface{}) error {
+	return d.validateRequest(href, "", method, payload)
+}
+
+// ValidateCreateRequest validates the body of the request which creates a resource in the collection href against
+// the schema of the create interface. Collections without the described create interface are not validated.
+func (d *Document) ValidateCreateRequest(href string, payload interface{}) error {
+	return d.validateRequest(href, interfaces.OC_IF_CREATE, "post", payload)
+}
+
+func (d *Document) validateRequest(href, iface, method string, payload interface{}) error {
+	s, ok, err := d.getRequestSchema(href, iface, method)
+	if err != nil {
+		return fmt.Errorf("cannot get schema of %v %v: %w", method, href, err)
+	}
+	if !ok {
+		return nil
+	}
+	v, err := normalizePayload(payload)
+	if err != nil {
+		return fmt.Errorf("%w of %v %v: cannot normalize payload: %w", ErrInvalidPayload, method, href, err)
+	}
+	if err = d.validate(s, v, "", true); err != nil {
+		return fmt.Errorf("%w of %v %v: %w", ErrInvalidPayload, method, href, err)
+	}
+	return nil
+}
+
+// normalizePayload converts the payload to the generic representation: map[string]interface{}, []interface{},
+// string, []byte, float64, bool or nil. Structures are converted via their CBOR encoding, because it is
+// the representation which is sent to the device.
+func normalizePayload(payload interface{}) (interface{}, error) {
+	return normalizeValue(reflect.ValueOf(payload))
+}
+
+func normalizeValue(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return normalizeValue(v.Elem())
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return normalizeArray(v)
+	case reflect.Array:
+		return normalizeArray(v)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return normalizeMap(v)
+	case reflect.Struct:
+		return normalizeStruct(v)
+	}
+	return nil, fmt.Errorf("unsupported type %v", v.Type())
+}
+
+func normalizeArray(v reflect.Value) (interface{}, error) {
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return b, nil
+	}
+	a := make([]interface{}, 0, v.Len())
+	for i := range v.Len() {
+		n, err := normalizeValue(v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, n)
+	}
+	return a, nil
+}
+
+func normalizeMap(v reflect.Value) (interface{}, error) {
+	m := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k := iter.Key()
+		if k.Kind() == reflect.Interface && !k.IsNil() {
+			k = k.Elem()
+		}
+		if k.Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported key type %v", k.Type())
+		}
+		n, err := normalizeValue(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		m[k.String()] = n
+	}
+	return m, nil
+}
+
+func normalizeStruct(v reflect.Value) (interface{}, error) {
+	data, err := cbor.Encode(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var n interface{}
+	if err = cbor.Decode(data, &n); err != nil {
+		return nil, err
+	}
+	return normalizeValue(reflect.ValueOf(n))
+}
+
+func pathOf(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func valueType(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string, []byte:
+		return "string"
+	case float64:
+		if val == math.Trunc(val) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func matchType(types []string, v interface{}) bool {
+	if len(types) == 0 {
+		return true
+	}
+	vt := valueType(v)
+	for _, t := range types {
+		if t == vt || (t == "number" && vt == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValue compares the normalized values.
+func equalValue(a, b interface{}) bool {
+	switch va := a.(type) {
+	case map[string]interface{}:
+		vb, ok := b.(map[string]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for k, item := range va {
+			bItem, ok := vb[k]
+			if !ok || !equalValue(item, bItem) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		vb, ok := b.([]interface{})
+		if !ok || len(va) != len(vb) {
+			return false
+		}
+		for i := range va {
+			if !equalValue(va[i], vb[i]) {
+				return false
+			}
+		}
+		return true
+	case []byte:
+		vb, ok := b.([]byte)
+		return ok && bytes.Equal(va, vb)
+	case nil, bool, string, float64:
+		return a == b
+	}
+	return false
+}
+
+func (d *Document) validateEnum(s *Schema, v interface{}) error {
+	if len(s.Enum) == 0 {
+		return nil
+	}
+	for _, e := range s.Enum {
+		ne, err := normalizePayload(e)
+		if err != nil {
+			return fmt.Errorf("invalid enum value %v: %w", e, err)
+		}
+		if equalValue(ne, v) {
+			return nil
+		}
+	}
+	return fmt.Errorf("value %v is not one of %v", v, s.Enum)
+}
+
+func validateNumber(s *Schema, v float64) error {
+	if s.Minimum != nil && v < *s.Minimum {
+		return fmt.Errorf("value %v is less than minimum %v", v, *s.Minimum)
+	}
+	if s.Maximum != nil && v > *s.Maximum {
+		return fmt.Errorf("value %v is greater than maximum %v", v, *s.Maximum)
+	}
+	return nil
+}
+
+func validateString(s *Schema, v string) error {
+	l := len([]rune(v))
+	if s.MinLength != nil && l < *s.MinLength {
+		return fmt.Errorf("length %v is less than minLength %v", l, *s.MinLength)
+	}
+	if s.MaxLength != nil && l > *s.MaxLength {
+		return fmt.Errorf("length %v is greater than maxLength %v", l, *s.MaxLength)
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			// ECMA regular expressions are not fully supported by regexp, so the pattern is skipped
+			return nil //nolint:nilerr
+		}
+		if !re.MatchString(v) {
+			return fmt.Errorf("value %v doesn't match pattern %v", v, s.Pattern)
+		}
+	}
+	return nil
+}
+
+func (d *Document) validateArray(s *Schema, v []interface{}, path string) error {
+	if s.MinItems != nil && len(v) < *s.MinItems {
+		return fmt.Errorf("number of items %v is less than minItems %v", len(v), *s.MinItems)
+	}
+	if s.MaxItems != nil && len(v) > *s.MaxItems {
+		return fmt.Errorf("number of items %v is greater than maxItems %v", len(v), *s.MaxItems)
+	}
+	if s.Items == nil {
+		return nil
+	}
+	for i, item := range v {
+		if err := d.validate(s.Items, item, fmt.Sprintf("%v[%v]", path, i), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Document) validateObject(s *Schema, v map[string]interface{}, path string, isRequest bool) error {
+	for _, r := range s.Required {
+		if _, ok := v[r]; !ok {
+			return fmt.Errorf("%v: required property is missing", pathOf(path, r))
+		}
+	}
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		ps, ok := s.Properties[k]
+		if !ok {
+			if allowed, isBool := s.AdditionalProperties.(bool); isBool && !allowed {
+				return fmt.Errorf("%v: property is not allowed", pathOf(path, k))
+			}
+			continue
+		}
+		ps, err := d.resolveSchema(ps)
+		if err != nil {
+			return fmt.Errorf("%v: %w", pathOf(path, k), err)
+		}
+		if isRequest && ps.ReadOnly {
+			return fmt.Errorf("%v: property is read-only", pathOf(path, k))
+		}
+		if err := d.validate(ps, v[k], pathOf(path, k), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Document) validateComposition(s *Schema, v interface{}, path string, isRequest bool) error {
+	for _, sub := range s.AllOf {
+		if err := d.validate(sub, v, path, isRequest); err != nil {
+			return err
+		}
+	}
+	if len(s.AnyOf) > 0 {
+		var errs []error
+		for _, sub := range s.AnyOf {
+			err := d.validate(sub, v, path, isRequest)
+			if err == nil {
+				errs = nil
+				break
+			}
+			errs = append(errs, err)
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%v: value doesn't match any schema: %w", path, errors.Join(errs...))
+		}
+	}
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, sub := range s.OneOf {
+			if d.validate(sub, v, path, isRequest) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return fmt.Errorf("%v: value matches %v schemas instead of exactly one", path, matched)
+		}
+	}
+	return nil
+}
+
+func (d *Document) validate(s *Schema, v interface{}, path string, isRequest bool) error {
+	s, err := d.resolveSchema(s)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return nil
+	}
+	if !matchType(s.types(), v) {
+		return fmt.Errorf("%v: invalid type %v, expected %v", path, valueType(v), s.types())
+	}
+	if err = d.validateEnum(s, v); err != nil {
+		return fmt.Errorf("%v: %w", path, err)
+	}
+	switch val := v.(type) {
+	case float64:
+		err = validateNumber(s, val)
+	case string:
+		err = validateString(s, val)
+	case []interface{}:
+		if err = d.validateArray(s, val, path); err != nil {
+			return err
+		}
+	case map[string]interface{}:
+		if err = d.validateObject(s, val, path, isRequest); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%v: %w", path, err)
+	}
+	return d.validateComposition(s, v, path, isRequest)
+}
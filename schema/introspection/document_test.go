@@ -0,0 +1,271 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package introspection_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/plgd-dev/device/v2/pkg/codec/cbor"
+	"github.com/plgd-dev/device/v2/schema/introspection"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDocument(t *testing.T) *introspection.Document {
+	minimum := float64(0)
+	maximum := float64(100)
+	maxLength := 8
+	doc := map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":   "test",
+			"version": "1.0",
+		},
+		"parameters": map[string]interface{}{
+			"interface": map[string]interface{}{
+				"in":   "query",
+				"name": "if",
+			},
+		},
+		"paths": map[string]interface{}{
+			"/lights?if=oic.if.create": map[string]interface{}{
+				"post": map[string]interface{}{
+					"parameters": []interface{}{
+						map[string]interface{}{
+							"in":     "body",
+							"name":   "body",
+							"schema": map[string]interface{}{"$ref": "#/definitions/CreateLight"},
+						},
+					},
+				},
+			},
+			"/lights?if=oic.if.baseline": map[string]interface{}{
+				"post": map[string]interface{}{
+					"parameters": []interface{}{
+						map[string]interface{}{
+							"in":     "body",
+							"name":   "body",
+							"schema": map[string]interface{}{"$ref": "#/definitions/Lights"},
+						},
+					},
+				},
+			},
+			"/light/1?if=oic.if.a": map[string]interface{}{
+				"post": map[string]interface{}{
+					"parameters": []interface{}{
+						map[string]interface{}{"$ref": "#/parameters/interface"},
+						map[string]interface{}{
+							"in":   "body",
+							"name": "body",
+							"schema": map[string]interface{}{
+								"$ref": "#/definitions/Light",
+							},
+						},
+					},
+				},
+			},
+		},
+		"definitions": map[string]interface{}{
+			"Lights": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"name"},
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "string"},
+				},
+			},
+			"CreateLight": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"rt", "if", "rep"},
+				"properties": map[string]interface{}{
+					"rt":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+					"if":  map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+					"rep": map[string]interface{}{"$ref": "#/definitions/Light"},
+				},
+			},
+			"Light": map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"state"},
+				"properties": map[string]interface{}{
+					"state": map[string]interface{}{"type": "boolean"},
+					"power": map[string]interface{}{"type": "integer", "minimum": minimum, "maximum": maximum},
+					"name":  map[string]interface{}{"type": "string", "maxLength": maxLength},
+					"mode":  map[string]interface{}{"type": "string", "enum": []interface{}{"auto", "manual"}},
+					"level": map[string]interface{}{"type": "integer", "enum": []interface{}{1, 2}},
+					"data":  map[string]interface{}{"type": "string"},
+					"rt": map[string]interface{}{
+						"type":     "array",
+						"readOnly": true,
+						"items":    map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+	data, err := cbor.Encode(doc)
+	require.NoError(t, err)
+	var d introspection.Document
+	err = cbor.Decode(data, &d)
+	require.NoError(t, err)
+	return &d
+}
+
+func TestDocumentValidateRequest(t *testing.T) {
+	doc := newTestDocument(t)
+
+	type light struct {
+		State *bool    `json:"state,omitempty"`
+		Power *int     `json:"power,omitempty"`
+		Name  string   `json:"name,omitempty"`
+		Mode  string   `json:"mode,omitempty"`
+		RT    []string `json:"rt,omitempty"`
+	}
+	state := true
+	power := 50
+	overPower := 101
+
+	tests := []struct {
+		name    string
+		href    string
+		payload interface{}
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			href:    "/light/1",
+			payload: light{State: &state, Power: &power, Mode: "auto"},
+		},
+		{
+			name:    "valid map",
+			href:    "/light/1",
+			payload: map[string]interface{}{"state": false},
+		},
+		{
+			name:    "valid map with interface keys",
+			href:    "/light/1",
+			payload: map[interface{}]interface{}{"state": true, "level": uint8(2)},
+		},
+		{
+			name:    "valid bytes",
+			href:    "/light/1",
+			payload: map[string]interface{}{"state": true, "data": []byte{0xff, 0x00}},
+		},
+		{
+			name:    "invalid key type",
+			href:    "/light/1",
+			payload: map[interface{}]interface{}{"state": true, 1: "one"},
+			wantErr: true,
+		},
+		{
+			name:    "integer not in enum",
+			href:    "/light/1",
+			payload: map[string]interface{}{"state": true, "level": 3},
+			wantErr: true,
+		},
+		{
+			name:    "missing required",
+			href:    "/light/1",
+			payload: light{Power: &power},
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			href:    "/light/1",
+			payload: map[string]interface{}{"state": "on"},
+			wantErr: true,
+		},
+		{
+			name:    "above maximum",
+			href:    "/light/1",
+			payload: light{State: &state, Power: &overPower},
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			href:    "/light/1",
+			payload: light{State: &state, Name: "very long name"},
+			wantErr: true,
+		},
+		{
+			name:    "not in enum",
+			href:    "/light/1",
+			payload: light{State: &state, Mode: "eco"},
+			wantErr: true,
+		},
+		{
+			name:    "read-only",
+			href:    "/light/1",
+			payload: light{State: &state, RT: []string{"oic.r.light"}},
+			wantErr: true,
+		},
+		{
+			name:    "not described",
+			href:    "/unknown",
+			payload: map[string]interface{}{"state": "on"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := doc.ValidateRequest(tt.href, "post", tt.payload)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, introspection.ErrInvalidPayload))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDocumentGetRequestSchema(t *testing.T) {
+	doc := newTestDocument(t)
+	s, ok, err := doc.GetRequestSchema("/light/1", "POST")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Contains(t, s.Properties, "state")
+
+	_, ok, err = doc.GetRequestSchema("/light/1", "put")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	var nilDoc *introspection.Document
+	_, ok, err = nilDoc.GetRequestSchema("/light/1", "post")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestDocumentValidateCreateRequest(t *testing.T) {
+	doc := newTestDocument(t)
+	child := map[string]interface{}{
+		"rt":  []string{"oic.r.light"},
+		"if":  []string{"oic.if.baseline"},
+		"rep": map[string]interface{}{"state": true},
+	}
+	require.NoError(t, doc.ValidateCreateRequest("/lights", child))
+	// the update of the collection is validated against the schema of the collection
+	require.NoError(t, doc.ValidateRequest("/lights", "post", map[string]interface{}{"name": "lights"}))
+	err := doc.ValidateRequest("/lights", "post", child)
+	require.ErrorIs(t, err, introspection.ErrInvalidPayload)
+
+	err = doc.ValidateCreateRequest("/lights", map[string]interface{}{"name": "lights"})
+	require.ErrorIs(t, err, introspection.ErrInvalidPayload)
+	child["rep"] = map[string]interface{}{"state": "on"}
+	err = doc.ValidateCreateRequest("/lights", child)
+	require.ErrorIs(t, err, introspection.ErrInvalidPayload)
+
+	// the create interface is not described
+	require.NoError(t, doc.ValidateCreateRequest("/light/1", map[string]interface{}{"state": "on"}))
+}
@@ -22,3 +22,17 @@ const (
 	ResourceType = "oic.wk.introspection"
 	ResourceURI  = "/oc/wk/introspection"
 )
+
+type Introspection struct {
+	ResourceTypes []string  `json:"rt,omitempty"`
+	Interfaces    []string  `json:"if,omitempty"`
+	Name          string    `json:"n,omitempty"`
+	URLInfo       []URLInfo `json:"urlInfo"`
+}
+
+type URLInfo struct {
+	URL         string `json:"url"`
+	Protocol    string `json:"protocol"`
+	ContentType string `json:"content-type,omitempty"`
+	Version     int    `json:"version,omitempty"`
+}
@@ -18,7 +18,40 @@
 // https://github.com/openconnectivityfoundation/security/blob/master/swagger2.0/oic.sec.roles.swagger.json
 package roles
 
+import "github.com/plgd-dev/device/v2/schema/credential"
+
 const (
 	ResourceType = "oic.r.roles"
 	ResourceURI  = "/oic/sec/roles"
 )
+
+// Response contains the role certificates asserted by the peer.
+type Response struct {
+	ResourceTypes []string                `json:"rt,omitempty"`
+	Interfaces    []string                `json:"if,omitempty"`
+	Roles         []credential.Credential `json:"roles"`
+}
+
+// UpdateRequest is used to assert the role certificates.
+type UpdateRequest struct {
+	Roles []credential.Credential `json:"roles"`
+}
+
+// NewUpdateRequest creates the request to assert the PEM encoded role certificates.
+func NewUpdateRequest(subject string, roleCertificates ...[]byte) UpdateRequest {
+	creds := make([]credential.Credential, 0, len(roleCertificates))
+	for _, cert := range roleCertificates {
+		creds = append(creds, credential.Credential{
+			Subject: subject,
+			Type:    credential.CredentialType_ASYMMETRIC_SIGNING_WITH_CERTIFICATE,
+			Usage:   credential.CredentialUsage_ROLE_CERT,
+			PublicData: &credential.CredentialPublicData{
+				DataInternal: string(cert),
+				Encoding:     credential.CredentialPublicDataEncoding_PEM,
+			},
+		})
+	}
+	return UpdateRequest{
+		Roles: creds,
+	}
+}
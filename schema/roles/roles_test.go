@@ -0,0 +1,38 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package roles_test
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/device/v2/schema/credential"
+	"github.com/plgd-dev/device/v2/schema/roles"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUpdateRequest(t *testing.T) {
+	subject := "00000000-0000-0000-0000-000000000001"
+	req := roles.NewUpdateRequest(subject, []byte("cert1"), []byte("cert2"))
+	require.Len(t, req.Roles, 2)
+	for _, r := range req.Roles {
+		require.Equal(t, subject, r.Subject)
+		require.Equal(t, credential.CredentialUsage_ROLE_CERT, r.Usage)
+		require.Equal(t, credential.CredentialType_ASYMMETRIC_SIGNING_WITH_CERTIFICATE, r.Type)
+		require.Equal(t, credential.CredentialPublicDataEncoding_PEM, r.PublicData.Encoding)
+	}
+	require.Equal(t, []byte("cert2"), req.Roles[1].PublicData.Data())
+}
@@ -22,3 +22,13 @@ const (
 	ResourceType = "oic.r.sdi"
 	ResourceURI  = "/oic/sec/sdi"
 )
+
+// SDI contains the supported fields of the Security Domain Information resource.
+type SDI struct {
+	// UUID identifies the security domain.
+	UUID string `json:"uuid"`
+	// Name is a human friendly name of the security domain.
+	Name string `json:"name,omitempty"`
+	// Private hides the security domain information from unauthenticated clients.
+	Private bool `json:"priv"`
+}
@@ -37,6 +37,12 @@ type SoftwareUpdate struct {
 	UpdateTime    string       `json:"updatetime,omitempty"`
 }
 
+type UpdateRequest struct {
+	PackageURL   string       `json:"purl,omitempty"`
+	UpdateAction UpdateAction `json:"swupdateaction,omitempty"`
+	UpdateTime   string       `json:"updatetime,omitempty"`
+}
+
 func (sw *SoftwareUpdate) GetUpdateResult() int {
 	if sw == nil || sw.UpdateResult == nil {
 		return -1
@@ -63,6 +69,11 @@ const (
 	UpdateState_UPGRADING              UpdateState = "upgrading"
 )
 
+const (
+	UpdateResult_IDLE    = 0 // no update has been processed yet
+	UpdateResult_SUCCESS = 1 // other positive values report a failure
+)
+
 type Signer string
 
 const (
@@ -18,7 +18,37 @@
 // https://github.com/openconnectivityfoundation/security/blob/master/swagger2.0/oic.sec.sp.swagger.json
 package sp
 
+import "slices"
+
 const (
 	ResourceType = "oic.r.sp"
 	ResourceURI  = "/oic/sec/sp"
 )
+
+// Security profiles defined by the OCF security specification.
+const (
+	Profile_BASELINE = "1.3.6.1.4.1.51414.0.0.1.0"
+	Profile_BLACK    = "1.3.6.1.4.1.51414.0.0.2.0"
+	Profile_BLUE     = "1.3.6.1.4.1.51414.0.0.3.0"
+	Profile_PURPLE   = "1.3.6.1.4.1.51414.0.0.4.0"
+)
+
+// SecurityProfile contains the supported fields of the Security Profile resource.
+type SecurityProfile struct {
+	ResourceTypes     []string `json:"rt,omitempty"`
+	Interfaces        []string `json:"if,omitempty"`
+	Name              string   `json:"n,omitempty"`
+	SupportedProfiles []string `json:"supportedprofiles"`
+	CurrentProfile    string   `json:"currentprofile"`
+}
+
+// IsSupported returns true when the profile is one of the supported profiles of the device.
+func (s SecurityProfile) IsSupported(profile string) bool {
+	return slices.Contains(s.SupportedProfiles, profile)
+}
+
+// SecurityProfileUpdateRequest is used to update the Security Profile resource.
+type SecurityProfileUpdateRequest struct {
+	SupportedProfiles []string `json:"supportedprofiles,omitempty"`
+	CurrentProfile    string   `json:"currentprofile,omitempty"`
+}
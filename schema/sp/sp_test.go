@@ -0,0 +1,35 @@
+// ************************************************************************
+// Copyright (C) 2026 plgd.dev, s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ************************************************************************
+
+package sp_test
+
+import (
+	"testing"
+
+	"github.com/plgd-dev/device/v2/schema/sp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityProfileIsSupported(t *testing.T) {
+	s := sp.SecurityProfile{
+		SupportedProfiles: []string{sp.Profile_BASELINE, sp.Profile_BLACK},
+		CurrentProfile:    sp.Profile_BASELINE,
+	}
+	require.True(t, s.IsSupported(sp.Profile_BASELINE))
+	require.True(t, s.IsSupported(sp.Profile_BLACK))
+	require.False(t, s.IsSupported(sp.Profile_BLUE))
+	require.False(t, sp.SecurityProfile{}.IsSupported(sp.Profile_BASELINE))
+}